@@ -0,0 +1,242 @@
+// cmd/baselines/main.go
+
+// Comando para listar, inspeccionar, aprobar y eliminar las referencias
+// almacenadas (instantáneas DOM y capturas) por página y entorno.
+//
+//	go run ./cmd/baselines list [-env prod] [-kind visual] [-page sandbox]
+//	go run ./cmd/baselines inspect -kind snapshots -page home
+//	go run ./cmd/baselines approve -page home        (promueve el resultado "actual")
+//	go run ./cmd/baselines approve -all              (promueve todos los pendientes)
+//	go run ./cmd/baselines reject -page home         (descarta el resultado "actual")
+//	go run ./cmd/baselines prune -env staging [-dry-run]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"GoLang_FRT_E2E_Tests/pkg/baselines"
+	"GoLang_FRT_E2E_Tests/pkg/snapshot"
+	"GoLang_FRT_E2E_Tests/pkg/visual"
+)
+
+const defaultRoot = "tests/e2e/testdata/baselines"
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Uso: baselines <list|inspect|approve|reject|prune> [opciones]\n")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command := os.Args[1]
+
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	root := fs.String("root", defaultRoot, "directorio raíz de las referencias")
+	env := fs.String("env", "", "entorno (vacío para todos)")
+	kind := fs.String("kind", "", "tipo de referencia: snapshots o visual (vacío para todos)")
+	page := fs.String("page", "", "nombre de la página (vacío para todas)")
+	all := fs.Bool("all", false, "aplicar a todas las referencias que cumplan el filtro")
+	dryRun := fs.Bool("dry-run", false, "mostrar lo que se haría sin modificar nada")
+	fs.Parse(os.Args[2:])
+
+	entries, err := baselines.List(*root, baselines.Filter{Env: *env, Kind: *kind, Name: *page})
+	if err != nil {
+		log.Fatalf("Error listando las referencias: %v", err)
+	}
+
+	switch command {
+	case "list":
+		list(entries)
+	case "inspect":
+		for _, entry := range entries {
+			inspect(entry)
+		}
+	case "approve":
+		requireSelection(*page, *all)
+		for _, entry := range pending(entries) {
+			if *dryRun {
+				log.Printf("Se aprobaría %s", entry.ActualPath)
+				continue
+			}
+			if _, err := baselines.Approve(entry); err != nil {
+				log.Fatalf("Error aprobando %s: %v", entry.ActualPath, err)
+			}
+			log.Printf("✅ Aprobada %s/%s/%s", entry.Env, entry.Kind, entry.Name)
+		}
+	case "reject":
+		requireSelection(*page, *all)
+		for _, entry := range pending(entries) {
+			if *dryRun {
+				log.Printf("Se descartaría %s", entry.ActualPath)
+				continue
+			}
+			if err := baselines.Reject(entry); err != nil {
+				log.Fatalf("Error descartando %s: %v", entry.ActualPath, err)
+			}
+			log.Printf("🗑️ Descartado el resultado pendiente de %s/%s/%s", entry.Env, entry.Kind, entry.Name)
+		}
+	case "prune":
+		if *env == "" && *kind == "" && *page == "" && !*all {
+			log.Fatalf("prune necesita -env, -kind, -page o -all")
+		}
+		for _, entry := range entries {
+			if *dryRun {
+				log.Printf("Se eliminaría %s/%s/%s", entry.Env, entry.Kind, entry.Name)
+				continue
+			}
+			if err := baselines.Remove(entry); err != nil {
+				log.Fatalf("Error eliminando %s/%s/%s: %v", entry.Env, entry.Kind, entry.Name, err)
+			}
+			log.Printf("🗑️ Eliminada %s/%s/%s", entry.Env, entry.Kind, entry.Name)
+		}
+	default:
+		usage()
+	}
+}
+
+// requireSelection evita aprobar o descartar todo por error
+func requireSelection(page string, all bool) {
+	if page == "" && !all {
+		log.Fatalf("Indica -page o -all")
+	}
+}
+
+func pending(entries []baselines.Entry) []baselines.Entry {
+	var result []baselines.Entry
+	for _, entry := range entries {
+		if entry.Pending() {
+			result = append(result, entry)
+		}
+	}
+	if len(result) == 0 {
+		log.Println("No hay resultados pendientes de revisión")
+	}
+	return result
+}
+
+func list(entries []baselines.Entry) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENTORNO\tTIPO\tPÁGINA\tTAMAÑO\tMODIFICADA\tESTADO")
+	for _, entry := range entries {
+		status := "ok"
+		if entry.Pending() {
+			status = "pendiente de revisión"
+		}
+		modified := "-"
+		if !entry.ModTime.IsZero() {
+			modified = entry.ModTime.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", entry.Env, entry.Kind, entry.Name, entry.Size, modified, status)
+	}
+	w.Flush()
+}
+
+func inspect(entry baselines.Entry) {
+	fmt.Printf("== %s/%s/%s\n", entry.Env, entry.Kind, entry.Name)
+	if entry.Path == "" {
+		fmt.Printf("   referencia: (sin aprobar)\n")
+	} else {
+		fmt.Printf("   referencia: %s\n", entry.Path)
+	}
+	if entry.Pending() {
+		fmt.Printf("   pendiente:  %s\n", entry.ActualPath)
+	}
+
+	switch entry.Kind {
+	case baselines.KindSnapshot:
+		inspectSnapshot(entry)
+	case baselines.KindVisual:
+		inspectVisual(entry)
+	default:
+		path := entry.Path
+		if path == "" {
+			path = entry.ActualPath
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("   error: %v\n", err)
+			return
+		}
+		fmt.Printf("%s\n", data)
+	}
+}
+
+// inspectSnapshot muestra la instantánea de referencia y su diff con la pendiente; si aún no hay
+// referencia aprobada, muestra la pendiente
+func inspectSnapshot(entry baselines.Entry) {
+	if entry.Path == "" {
+		actual, err := snapshot.LoadFile(entry.ActualPath)
+		if err != nil {
+			fmt.Printf("   error: %v\n", err)
+			return
+		}
+		fmt.Printf("   título: %q, secciones: %d, enlaces: %d\n", actual.Title, len(actual.Sections), len(actual.Links))
+		return
+	}
+
+	expected, err := snapshot.LoadFile(entry.Path)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   título: %q, secciones: %d, enlaces: %d\n", expected.Title, len(expected.Sections), len(expected.Links))
+	if !entry.Pending() {
+		return
+	}
+
+	actual, err := snapshot.LoadFile(entry.ActualPath)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Print(snapshot.Compare(expected, actual))
+}
+
+// inspectVisual muestra el tamaño de la captura de referencia y su diferencia con la pendiente;
+// si aún no hay referencia aprobada, muestra el tamaño de la pendiente
+func inspectVisual(entry baselines.Entry) {
+	if entry.Path == "" {
+		actual, err := decodePNG(entry.ActualPath)
+		if err != nil {
+			fmt.Printf("   error: %v\n", err)
+			return
+		}
+		fmt.Printf("   tamaño: %dx%d\n", actual.Bounds().Dx(), actual.Bounds().Dy())
+		return
+	}
+
+	expected, err := decodePNG(entry.Path)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   tamaño: %dx%d\n", expected.Bounds().Dx(), expected.Bounds().Dy())
+	if !entry.Pending() {
+		return
+	}
+
+	actual, err := decodePNG(entry.ActualPath)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   diferencia: %s\n", visual.Compare(expected, actual, visual.DefaultOptions()))
+}
+
+func decodePNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
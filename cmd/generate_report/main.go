@@ -55,6 +55,13 @@ func main() {
             }
             // Resetear currentTest después de que el test termina
             currentTest = ""
+        } else if strings.Contains(line, "🖼️") {
+            // Adjuntar el tríptico de la diferencia visual al test actual
+            if result, exists := testResults[currentTest]; exists {
+                fields := strings.Fields(line)
+                result.Images = append(result.Images, fields[len(fields)-1])
+                result.Logs = append(result.Logs, line)
+            }
         } else if strings.Contains(line, "❌ Error") {
             // Detectar error en el test
             if result, exists := testResults[currentTest]; exists {
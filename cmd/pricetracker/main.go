@@ -0,0 +1,139 @@
+// cmd/pricetracker/main.go
+
+// Comando para vigilar los precios de Avis: ejecuta SearchVehicles sobre la matriz
+// de escenarios definida en YAML, guarda los precios en un fichero JSON lines y
+// compara la ejecución con la anterior para informar de cambios y anomalías.
+//
+//	go run ./cmd/pricetracker [-matrix config/pricing_matrix.yaml] [-store data/prices.jsonl]
+//	go run ./cmd/pricetracker -report-only          (solo compara las dos últimas ejecuciones)
+//	go run ./cmd/pricetracker -threshold 0.15 -fail-on-anomaly
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/pricing"
+)
+
+const urlAvis = "https://www.avis.es"
+
+func main() {
+	matrixPath := flag.String("matrix", "config/pricing_matrix.yaml", "fichero YAML con la matriz de escenarios")
+	storePath := flag.String("store", "data/prices.jsonl", "fichero JSON lines donde se guardan los precios")
+	threshold := flag.Float64("threshold", pricing.DefaultOptions().JumpThreshold, "variación relativa de precio considerada anómala")
+	reportOnly := flag.Bool("report-only", false, "no buscar, solo comparar las dos últimas ejecuciones")
+	failOnAnomaly := flag.Bool("fail-on-anomaly", false, "terminar con código 1 si se detectan anomalías")
+	flag.Parse()
+
+	store := pricing.NewStore(*storePath)
+
+	if !*reportOnly {
+		matrix, err := pricing.LoadMatrix(*matrixPath)
+		if err != nil {
+			log.Fatalf("Error leyendo la matriz: %v", err)
+		}
+		scenarios, err := matrix.Scenarios(time.Now())
+		if err != nil {
+			log.Fatalf("Error expandiendo la matriz: %v", err)
+		}
+		log.Printf("🚀 %d escenarios a buscar", len(scenarios))
+
+		records := track(scenarios)
+		if err := store.Append(records); err != nil {
+			log.Fatalf("Error guardando los precios: %v", err)
+		}
+		log.Printf("💾 %d registros guardados en %s", len(records), *storePath)
+	}
+
+	previous, latest, err := store.LastTwo()
+	if err != nil {
+		log.Fatalf("Error leyendo %s: %v", *storePath, err)
+	}
+	if latest == nil {
+		log.Fatalf("No hay ejecuciones en %s", *storePath)
+	}
+
+	report := pricing.Compare(previous, latest, pricing.Options{JumpThreshold: *threshold})
+	fmt.Print(report)
+
+	if *failOnAnomaly && len(report.Anomalies) > 0 {
+		os.Exit(1)
+	}
+}
+
+// track busca cada escenario con una única sesión del navegador y devuelve los registros de la ejecución
+func track(scenarios []pricing.Scenario) []pricing.Record {
+	started := time.Now()
+	runID := pricing.NewRunID(started)
+
+	avisPage := pages.NewAvisPageWithSession(emulation.Spain())
+	defer avisPage.Close()
+
+	var records []pricing.Record
+	cookiesAccepted := false
+	for i, scenario := range scenarios {
+		log.Printf("🔎 [%d/%d] %s", i+1, len(scenarios), scenario.ID)
+		base := pricing.Record{
+			RunID:          runID,
+			Timestamp:      started,
+			Scenario:       scenario.ID,
+			PickupLocation: scenario.PickupLocation,
+			ReturnLocation: scenario.ReturnLocation,
+			PickupTime:     scenario.PickupTime,
+			ReturnTime:     scenario.ReturnTime,
+		}
+
+		vehicles, err := search(avisPage, scenario, &cookiesAccepted)
+		if err != nil {
+			log.Printf("❌ %s: %v", scenario.ID, err)
+			base.Error = err.Error()
+			records = append(records, base)
+			continue
+		}
+
+		for _, vehicle := range vehicles {
+			record := base
+			record.Model = vehicle.Model
+			record.Category = vehicle.Category
+			record.ACRISS = vehicle.ACRISS
+			record.PayNow = vehicle.PayNow.Amount
+			record.PayLater = vehicle.PayLater.Amount
+			record.Currency = vehicle.LowestPrice().Currency
+			record.Available = vehicle.Available
+			records = append(records, record)
+		}
+		log.Printf("🚗 %d vehículos", len(vehicles))
+	}
+	return records
+}
+
+// search realiza la búsqueda de un escenario. El banner de cookies solo se acepta la primera vez,
+// porque el consentimiento se conserva en el contexto del navegador.
+func search(avisPage *pages.AvisPage, scenario pricing.Scenario, cookiesAccepted *bool) ([]pages.Vehicle, error) {
+	if err := avisPage.NavigateTo(urlAvis); err != nil {
+		return nil, err
+	}
+	if !*cookiesAccepted {
+		if err := avisPage.AcceptCookies(); err != nil {
+			return nil, fmt.Errorf("aceptando cookies: %w", err)
+		}
+		*cookiesAccepted = true
+	}
+
+	results, err := avisPage.SearchVehicles(pages.SearchCriteria{
+		PickupLocation: scenario.PickupLocation,
+		ReturnLocation: scenario.ReturnLocation,
+		PickupTime:     scenario.PickupTime,
+		ReturnTime:     scenario.ReturnTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results.Vehicles()
+}
@@ -0,0 +1,194 @@
+// pkg/baselines/baselines.go
+
+// Package baselines organiza las referencias almacenadas (instantáneas DOM y
+// capturas de pantalla) por entorno, tipo y página, y permite listarlas, aprobar
+// los resultados pendientes y eliminarlas.
+package baselines
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Tipos de referencia soportados
+const (
+	KindSnapshot = "snapshots"
+	KindVisual   = "visual"
+)
+
+// DefaultEnv es el entorno que se usa si no se define BASELINE_ENV
+const DefaultEnv = "default"
+
+// actualMarker es la marca que identifica los artefactos "actual" de una ejecución fallida
+const actualMarker = ".actual"
+
+// Kinds contiene los tipos de referencia conocidos
+var Kinds = []string{KindSnapshot, KindVisual}
+
+// Env devuelve el entorno activo según la variable BASELINE_ENV
+func Env() string {
+	if env := strings.TrimSpace(os.Getenv("BASELINE_ENV")); env != "" {
+		return env
+	}
+	return DefaultEnv
+}
+
+// Dir devuelve el directorio de referencias de un entorno y tipo
+func Dir(root, env, kind string) string {
+	return filepath.Join(root, env, kind)
+}
+
+// Entry representa una referencia almacenada y, si existe, su resultado pendiente de aprobar
+type Entry struct {
+	Env        string
+	Kind       string
+	Name       string
+	Path       string
+	ActualPath string
+	Size       int64
+	ModTime    time.Time
+}
+
+// Pending indica si hay un resultado "actual" pendiente de revisión
+func (e Entry) Pending() bool {
+	return e.ActualPath != ""
+}
+
+// Filter selecciona referencias por entorno, tipo y página; los campos vacíos no filtran
+type Filter struct {
+	Env  string
+	Kind string
+	Name string
+}
+
+func (f Filter) match(e Entry) bool {
+	return (f.Env == "" || f.Env == e.Env) &&
+		(f.Kind == "" || f.Kind == e.Kind) &&
+		(f.Name == "" || f.Name == e.Name)
+}
+
+// List recorre el directorio raíz y devuelve las referencias que cumplen el filtro
+func List(root string, filter Filter) ([]Entry, error) {
+	entries := make(map[string]*Entry)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != 3 {
+			return nil
+		}
+		env, kind, file := parts[0], parts[1], parts[2]
+
+		ext := filepath.Ext(file)
+		name := strings.TrimSuffix(file, ext)
+		actual := strings.HasSuffix(name, actualMarker)
+		name = strings.TrimSuffix(name, actualMarker)
+
+		key := env + "/" + kind + "/" + name
+		entry, ok := entries[key]
+		if !ok {
+			entry = &Entry{Env: env, Kind: kind, Name: name}
+			entries[key] = entry
+		}
+		if actual {
+			entry.ActualPath = path
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry.Path = path
+		entry.Size = info.Size()
+		entry.ModTime = info.ModTime()
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var result []Entry
+	for _, entry := range entries {
+		if filter.match(*entry) {
+			result = append(result, *entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Env != b.Env {
+			return a.Env < b.Env
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// ActualPath devuelve la ruta del artefacto "actual" correspondiente a una referencia
+func ActualPath(baselinePath string) string {
+	ext := filepath.Ext(baselinePath)
+	return strings.TrimSuffix(baselinePath, ext) + actualMarker + ext
+}
+
+// Approve promueve el resultado "actual" pendiente a nueva referencia
+func Approve(entry Entry) (Entry, error) {
+	if !entry.Pending() {
+		return entry, fmt.Errorf("%s/%s/%s has no pending result to approve", entry.Env, entry.Kind, entry.Name)
+	}
+
+	target := entry.Path
+	if target == "" {
+		ext := filepath.Ext(entry.ActualPath)
+		target = strings.TrimSuffix(entry.ActualPath, actualMarker+ext) + ext
+	}
+	if err := os.Rename(entry.ActualPath, target); err != nil {
+		return entry, err
+	}
+
+	entry.Path = target
+	entry.ActualPath = ""
+	if info, err := os.Stat(target); err == nil {
+		entry.Size = info.Size()
+		entry.ModTime = info.ModTime()
+	}
+	return entry, nil
+}
+
+// Reject descarta el resultado "actual" pendiente y conserva la referencia
+func Reject(entry Entry) error {
+	if !entry.Pending() {
+		return nil
+	}
+	return os.Remove(entry.ActualPath)
+}
+
+// Remove elimina la referencia y su resultado pendiente, si lo hay
+func Remove(entry Entry) error {
+	for _, path := range []string{entry.Path, entry.ActualPath} {
+		if path == "" {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+// pkg/consent/consent.go
+
+// Package consent detecta los banners de consentimiento de cookies más habituales
+// (OneTrust, Cookiebot, Didomi, Tealium o selectores propios definidos en configuración)
+// y acepta, rechaza o personaliza el consentimiento tanto en chromedp como en Playwright.
+// Que no aparezca ningún banner no se considera un error.
+package consent
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action es lo que se hace con el banner de consentimiento
+type Action string
+
+const (
+	ActionNone      Action = ""
+	ActionAccept    Action = "accept"
+	ActionReject    Action = "reject"
+	ActionCustomize Action = "customize"
+)
+
+// Categorías de cookies comunes a todos los proveedores
+const (
+	CategoryPreferences = "preferences"
+	CategoryStatistics  = "statistics"
+	CategoryMarketing   = "marketing"
+)
+
+// Decision indica cómo responder al banner. Con ActionCustomize, Categories dice qué
+// categorías no esenciales se permiten; las que no aparecen se deniegan.
+type Decision struct {
+	Action     Action
+	Categories map[string]bool
+}
+
+// Accept acepta todas las cookies
+func Accept() Decision { return Decision{Action: ActionAccept} }
+
+// Reject rechaza todas las cookies no esenciales
+func Reject() Decision { return Decision{Action: ActionReject} }
+
+// Customize permite solo las categorías indicadas
+func Customize(categories map[string]bool) Decision {
+	return Decision{Action: ActionCustomize, Categories: categories}
+}
+
+// Provider describe los selectores del banner de una plataforma de consentimiento (CMP)
+type Provider struct {
+	Name string `yaml:"name"`
+	// Banner es el selector cuyo elemento visible indica que el banner se está mostrando
+	Banner    string `yaml:"banner"`
+	Accept    string `yaml:"accept"`
+	Reject    string `yaml:"reject"`
+	Customize string `yaml:"customize"`
+	Save      string `yaml:"save"`
+	// Categories relaciona cada categoría con la casilla que la activa en el panel de preferencias
+	Categories map[string]string `yaml:"categories"`
+}
+
+// OneTrust es el banner de OneTrust
+var OneTrust = Provider{
+	Name:      "onetrust",
+	Banner:    "#onetrust-banner-sdk",
+	Accept:    "#onetrust-accept-btn-handler",
+	Reject:    "#onetrust-reject-all-handler",
+	Customize: "#onetrust-pc-btn-handler",
+	Save:      ".save-preference-btn-handler",
+	Categories: map[string]string{
+		CategoryPreferences: "#ot-group-id-C0003",
+		CategoryStatistics:  "#ot-group-id-C0002",
+		CategoryMarketing:   "#ot-group-id-C0004",
+	},
+}
+
+// Cookiebot es el banner de Cookiebot
+var Cookiebot = Provider{
+	Name:      "cookiebot",
+	Banner:    "#CybotCookiebotDialog",
+	Accept:    "#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll",
+	Reject:    "#CybotCookiebotDialogBodyButtonDecline",
+	Customize: "#CybotCookiebotDialogBodyLevelButtonCustomize",
+	Save:      "#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowallSelection",
+	Categories: map[string]string{
+		CategoryPreferences: "#CybotCookiebotDialogBodyLevelButtonPreferences",
+		CategoryStatistics:  "#CybotCookiebotDialogBodyLevelButtonStatistics",
+		CategoryMarketing:   "#CybotCookiebotDialogBodyLevelButtonMarketing",
+	},
+}
+
+// Didomi es el banner de Didomi
+var Didomi = Provider{
+	Name:      "didomi",
+	Banner:    "#didomi-notice",
+	Accept:    "#didomi-notice-agree-button",
+	Reject:    "#didomi-notice-disagree-button, .didomi-continue-without-agreeing",
+	Customize: "#didomi-notice-learn-more-button",
+	Save:      ".didomi-consent-popup-actions button.didomi-button-highlight",
+}
+
+// Tealium es el banner de Tealium (consent prompt) que usa avis.es
+var Tealium = Provider{
+	Name:      "tealium",
+	Banner:    "#__tealiumGDPRecModal, #consent_prompt_accept",
+	Accept:    "#consent_prompt_accept",
+	Reject:    "#consent_prompt_decline",
+	Customize: "#consent_prompt_preferences",
+	Save:      "#preferences_prompt_submit",
+	Categories: map[string]string{
+		CategoryPreferences: "#preferences_prompt_personalization",
+		CategoryStatistics:  "#preferences_prompt_analytics",
+		CategoryMarketing:   "#preferences_prompt_display_ads",
+	},
+}
+
+// DefaultProviders son los proveedores que se detectan por defecto, en este orden
+var DefaultProviders = []Provider{OneTrust, Cookiebot, Didomi, Tealium}
+
+// DefaultTimeout es el tiempo que se espera a que aparezca algún banner
+const DefaultTimeout = 5 * time.Second
+
+// pollInterval es la frecuencia con la que se busca el banner
+const pollInterval = 250 * time.Millisecond
+
+// Manager detecta el banner de alguno de sus proveedores y aplica la decisión
+type Manager struct {
+	Providers []Provider
+	Timeout   time.Duration
+}
+
+// NewManager crea un gestor con los proveedores por defecto y los adicionales indicados,
+// que tienen prioridad sobre los predeterminados
+func NewManager(extra ...Provider) *Manager {
+	return &Manager{
+		Providers: append(append([]Provider(nil), extra...), DefaultProviders...),
+		Timeout:   DefaultTimeout,
+	}
+}
+
+// ManagerFromEnv crea un gestor con los proveedores por defecto y, si CONSENT_PROVIDERS
+// apunta a un fichero YAML, con los proveedores propios definidos en él
+func ManagerFromEnv() (*Manager, error) {
+	path := strings.TrimSpace(os.Getenv("CONSENT_PROVIDERS"))
+	if path == "" {
+		return NewManager(), nil
+	}
+	providers, err := LoadProviders(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewManager(providers...), nil
+}
+
+// config es el formato del fichero de proveedores propios
+type config struct {
+	Providers []Provider `yaml:"providers"`
+}
+
+// LoadProviders lee proveedores propios desde un fichero YAML
+func LoadProviders(path string) ([]Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("configuración de consentimiento inválida: %w", err)
+	}
+	for _, provider := range cfg.Providers {
+		if provider.Name == "" || provider.Banner == "" {
+			return nil, fmt.Errorf("proveedor de consentimiento sin name o banner: %+v", provider)
+		}
+	}
+	return cfg.Providers, nil
+}
+
+// Result describe lo que se encontró y se hizo con el banner
+type Result struct {
+	// Provider es el nombre del proveedor detectado, vacío si no apareció ningún banner
+	Provider string
+	Action   Action
+}
+
+// Shown indica si se mostró algún banner
+func (r Result) Shown() bool {
+	return r.Provider != ""
+}
+
+// driver abstrae las operaciones que necesita el gestor en chromedp y Playwright
+type driver interface {
+	visible(selector string) (bool, error)
+	click(selector string) error
+	setChecked(selector string, checked bool) error
+}
+
+// apply busca un banner y aplica la decisión con el driver indicado
+func (m *Manager) apply(d driver, decision Decision) (Result, error) {
+	if decision.Action == ActionNone {
+		return Result{}, nil
+	}
+
+	provider, err := m.detect(d)
+	if err != nil || provider == nil {
+		return Result{}, err
+	}
+	result := Result{Provider: provider.Name, Action: decision.Action}
+
+	switch decision.Action {
+	case ActionAccept:
+		return result, clickRequired(d, provider.Name, "accept", provider.Accept)
+	case ActionReject:
+		return result, clickRequired(d, provider.Name, "reject", provider.Reject)
+	case ActionCustomize:
+		return result, customize(d, provider, decision.Categories)
+	default:
+		return result, fmt.Errorf("acción de consentimiento desconocida %q", decision.Action)
+	}
+}
+
+// detect espera hasta Timeout a que algún proveedor muestre su banner; devuelve nil si no aparece ninguno
+func (m *Manager) detect(d driver) (*Provider, error) {
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		for i := range m.Providers {
+			shown, err := d.visible(m.Providers[i].Banner)
+			if err != nil {
+				return nil, err
+			}
+			if shown {
+				return &m.Providers[i], nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// clickRequired pulsa el botón del proveedor, que debe estar definido y hacerse visible
+// en DefaultTimeout (los paneles de preferencias suelen abrirse con una animación)
+func clickRequired(d driver, provider, action, selector string) error {
+	if selector == "" {
+		return fmt.Errorf("el proveedor %s no define el botón %s", provider, action)
+	}
+
+	deadline := time.Now().Add(DefaultTimeout)
+	for {
+		shown, err := d.visible(selector)
+		if err != nil {
+			return err
+		}
+		if shown {
+			return d.click(selector)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("el botón %s de %s (%s) no está visible", action, provider, selector)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// customize abre el panel de preferencias, marca solo las categorías permitidas y guarda
+func customize(d driver, provider *Provider, categories map[string]bool) error {
+	if err := clickRequired(d, provider.Name, "customize", provider.Customize); err != nil {
+		return err
+	}
+
+	unknown := make([]string, 0)
+	for category := range categories {
+		if _, ok := provider.Categories[category]; !ok {
+			unknown = append(unknown, category)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("el proveedor %s no define las categorías %s", provider.Name, strings.Join(unknown, ", "))
+	}
+
+	names := make([]string, 0, len(provider.Categories))
+	for category := range provider.Categories {
+		names = append(names, category)
+	}
+	sort.Strings(names)
+	for _, category := range names {
+		if err := d.setChecked(provider.Categories[category], categories[category]); err != nil {
+			return fmt.Errorf("categoría %s de %s: %w", category, provider.Name, err)
+		}
+	}
+
+	return clickRequired(d, provider.Name, "save", provider.Save)
+}
@@ -0,0 +1,119 @@
+// pkg/consent/drivers.go
+
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+)
+
+// visibleScript indica si algún elemento que coincide con el selector se está mostrando
+const visibleScript = `(function(selector) {
+	return Array.from(document.querySelectorAll(selector)).some(el => {
+		const style = window.getComputedStyle(el);
+		const r = el.getBoundingClientRect();
+		return style.display !== 'none' && style.visibility !== 'hidden' && style.opacity !== '0' && r.width > 0 && r.height > 0;
+	});
+})(%s)`
+
+// clickScript pulsa el primer elemento visible que coincide con el selector
+const clickScript = `(function(selector) {
+	const el = Array.from(document.querySelectorAll(selector)).find(e => e.getBoundingClientRect().width > 0) ||
+		document.querySelector(selector);
+	if (!el) return false;
+	el.click();
+	return true;
+})(%s)`
+
+// setCheckedScript deja la casilla en el estado pedido pulsándola, para que la CMP registre el cambio
+const setCheckedScript = `(function(selector, checked) {
+	const el = document.querySelector(selector);
+	if (!el) return false;
+	if (el.checked !== checked) el.click();
+	return true;
+})(%s, %t)`
+
+// Chromedp devuelve una acción que aplica la decisión en la pestaña de chromedp y deja
+// en result lo que se encontró. Puede incluirse en cualquier lista de tareas tras navegar.
+func (m *Manager) Chromedp(decision Decision, result *Result) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		r, err := m.apply(chromedpDriver{ctx}, decision)
+		if result != nil {
+			*result = r
+		}
+		return err
+	})
+}
+
+// Playwright aplica la decisión en la página de Playwright
+func (m *Manager) Playwright(page playwright.Page, decision Decision) (Result, error) {
+	return m.apply(playwrightDriver{page}, decision)
+}
+
+// chromedpDriver implementa driver evaluando JavaScript en la pestaña, sin esperas bloqueantes
+type chromedpDriver struct {
+	ctx context.Context
+}
+
+func (d chromedpDriver) eval(script string, selector string, extra ...any) (bool, error) {
+	quoted, err := json.Marshal(selector)
+	if err != nil {
+		return false, err
+	}
+	var ok bool
+	err = chromedp.Evaluate(fmt.Sprintf(script, append([]any{string(quoted)}, extra...)...), &ok).Do(d.ctx)
+	return ok, err
+}
+
+func (d chromedpDriver) visible(selector string) (bool, error) {
+	return d.eval(visibleScript, selector)
+}
+
+func (d chromedpDriver) click(selector string) error {
+	ok, err := d.eval(clickScript, selector)
+	if err == nil && !ok {
+		err = fmt.Errorf("no se encuentra %s", selector)
+	}
+	return err
+}
+
+func (d chromedpDriver) setChecked(selector string, checked bool) error {
+	ok, err := d.eval(setCheckedScript, selector, checked)
+	if err == nil && !ok {
+		err = fmt.Errorf("no se encuentra %s", selector)
+	}
+	return err
+}
+
+// playwrightDriver implementa driver con los localizadores de Playwright
+type playwrightDriver struct {
+	page playwright.Page
+}
+
+func (d playwrightDriver) visible(selector string) (bool, error) {
+	elements, err := d.page.Locator(selector).All()
+	if err != nil {
+		return false, err
+	}
+	for _, element := range elements {
+		if shown, _ := element.IsVisible(); shown {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d playwrightDriver) click(selector string) error {
+	return d.page.Locator(selector).Locator("visible=true").First().Click()
+}
+
+func (d playwrightDriver) setChecked(selector string, checked bool) error {
+	// Las CMP suelen ocultar la casilla real tras un interruptor dibujado con CSS
+	return d.page.Locator(selector).First().SetChecked(checked, playwright.LocatorSetCheckedOptions{
+		Force: playwright.Bool(true),
+	})
+}
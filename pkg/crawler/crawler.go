@@ -0,0 +1,271 @@
+// pkg/crawler/crawler.go
+
+// Package crawler recorre un sitio a partir de una URL inicial, siguiendo los
+// enlaces internos, y ejecuta las comprobaciones estáticas en cada página.
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/links"
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// PageInfo contiene el inventario de una página rastreada
+type PageInfo struct {
+	URL           string
+	Depth         int
+	StatusCode    int
+	Title         string
+	SectionCount  int
+	OutboundLinks []string
+	Issues        []lint.Issue
+	LinkReport    *links.Report
+	Err           error
+}
+
+// Inventory es el resultado de un rastreo completo
+type Inventory struct {
+	StartURL   string
+	Pages      []PageInfo
+	Disallowed []string
+}
+
+// Failed devuelve las páginas que no se pudieron obtener o que tienen errores de lint o enlaces rotos
+func (inv *Inventory) Failed() []PageInfo {
+	var failed []PageInfo
+	for _, page := range inv.Pages {
+		if page.Err != nil || page.StatusCode >= 400 || len(lint.Errors(page.Issues)) > 0 ||
+			(page.LinkReport != nil && len(page.LinkReport.Broken()) > 0) {
+			failed = append(failed, page)
+		}
+	}
+	return failed
+}
+
+// Crawler rastrea las páginas internas de un sitio
+type Crawler struct {
+	StartURL     string
+	MaxDepth     int
+	MaxPages     int
+	Delay        time.Duration
+	UserAgent    string
+	UseRobots    bool
+	UseSitemap   bool
+	SectionQuery string
+	// LinkChecker verifica los enlaces de cada página rastreada; nil desactiva la verificación
+	LinkChecker *links.Checker
+	client      *http.Client
+}
+
+// New crea un nuevo Crawler con valores por defecto
+func New(startURL string) *Crawler {
+	return &Crawler{
+		StartURL:     startURL,
+		MaxDepth:     2,
+		MaxPages:     50,
+		Delay:        250 * time.Millisecond,
+		UserAgent:    "FreeRangeTesters E2E Tests",
+		UseRobots:    true,
+		UseSitemap:   true,
+		SectionQuery: "[id^='page_section']",
+		LinkChecker:  links.NewChecker(),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// SetClient sustituye el cliente HTTP utilizado por el Crawler
+func (c *Crawler) SetClient(client *http.Client) {
+	c.client = client
+}
+
+type queued struct {
+	url   string
+	depth int
+}
+
+// Crawl rastrea el sitio en anchura respetando profundidad, límite de páginas y robots.txt
+func (c *Crawler) Crawl(ctx context.Context) (*Inventory, error) {
+	start, err := url.Parse(c.StartURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start URL: %w", err)
+	}
+	start.Fragment = ""
+
+	var robots *Robots
+	if c.UseRobots {
+		robots = c.fetchRobots(ctx, start)
+	}
+
+	inventory := &Inventory{StartURL: start.String()}
+	seen := map[string]bool{start.String(): true}
+	queue := []queued{{url: start.String(), depth: 0}}
+
+	// Las URLs del sitemap cuentan como enlazadas desde la página inicial, a profundidad 1
+	if c.UseSitemap && c.MaxDepth >= 1 {
+		for _, loc := range c.fetchSitemap(ctx, start, robots) {
+			u, err := url.Parse(loc)
+			if err != nil || !links.SameSite(start, u) || seen[u.String()] {
+				continue
+			}
+			seen[u.String()] = true
+			queue = append(queue, queued{url: u.String(), depth: 1})
+		}
+	}
+
+	for len(queue) > 0 && len(inventory.Pages) < c.MaxPages {
+		if err := ctx.Err(); err != nil {
+			return inventory, err
+		}
+		item := queue[0]
+		queue = queue[1:]
+
+		u, _ := url.Parse(item.url)
+		if !robots.Allowed(u.RequestURI()) {
+			inventory.Disallowed = append(inventory.Disallowed, item.url)
+			continue
+		}
+
+		if len(inventory.Pages) > 0 && c.Delay > 0 {
+			select {
+			case <-time.After(c.Delay):
+			case <-ctx.Done():
+				return inventory, ctx.Err()
+			}
+		}
+
+		info, next := c.visit(ctx, item)
+		inventory.Pages = append(inventory.Pages, info)
+
+		if item.depth >= c.MaxDepth {
+			continue
+		}
+		for _, link := range next {
+			if !seen[link] {
+				seen[link] = true
+				queue = append(queue, queued{url: link, depth: item.depth + 1})
+			}
+		}
+	}
+
+	return inventory, nil
+}
+
+// visit descarga una página, ejecuta las comprobaciones y devuelve los enlaces internos a seguir
+func (c *Crawler) visit(ctx context.Context, item queued) (PageInfo, []string) {
+	info := PageInfo{URL: item.url, Depth: item.depth}
+
+	resp, err := c.get(ctx, item.url)
+	if err != nil {
+		info.Err = err
+		return info, nil
+	}
+	defer resp.Body.Close()
+
+	info.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return info, nil
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return info, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		info.Err = fmt.Errorf("error parsing HTML: %w", err)
+		return info, nil
+	}
+
+	info.Title = strings.TrimSpace(doc.Find("title").First().Text())
+	info.SectionCount = doc.Find(c.SectionQuery).Length()
+	info.OutboundLinks = links.Extract(doc)
+	info.Issues = lint.Check(doc)
+
+	// Las redirecciones cambian la base contra la que se resuelven los enlaces
+	base := resp.Request.URL
+	if c.LinkChecker != nil {
+		report, err := c.LinkChecker.Check(ctx, base.String(), info.OutboundLinks)
+		if err != nil {
+			info.Err = err
+		}
+		info.LinkReport = report
+	}
+
+	var next []string
+	for _, raw := range info.OutboundLinks {
+		link := links.Classify(base, raw)
+		if link.Kind == links.KindInternal {
+			next = append(next, link.Resolved)
+		}
+	}
+	return info, next
+}
+
+// fetchRobots descarga robots.txt; si no existe se permite todo
+func (c *Crawler) fetchRobots(ctx context.Context, start *url.URL) *Robots {
+	resp, err := c.get(ctx, start.ResolveReference(&url.URL{Path: "/robots.txt"}).String())
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	robots, err := ParseRobots(resp.Body, c.UserAgent)
+	if err != nil {
+		return nil
+	}
+	return robots
+}
+
+// fetchSitemap obtiene las URLs de los sitemaps declarados en robots.txt o de /sitemap.xml
+func (c *Crawler) fetchSitemap(ctx context.Context, start *url.URL, robots *Robots) []string {
+	pending := []string{start.ResolveReference(&url.URL{Path: "/sitemap.xml"}).String()}
+	if robots != nil && len(robots.Sitemaps) > 0 {
+		pending = robots.Sitemaps
+	}
+
+	var pages []string
+	visited := make(map[string]bool)
+	for len(pending) > 0 && len(pages) < c.MaxPages {
+		loc := pending[0]
+		pending = pending[1:]
+		if visited[loc] {
+			continue
+		}
+		visited[loc] = true
+
+		resp, err := c.get(ctx, loc)
+		if err != nil {
+			continue
+		}
+		if resp.StatusCode == http.StatusOK {
+			found, nested, err := parseSitemap(resp.Body)
+			if err == nil {
+				pages = append(pages, found...)
+				pending = append(pending, nested...)
+			}
+		}
+		resp.Body.Close()
+	}
+	return pages
+}
+
+func (c *Crawler) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	return c.client.Do(req)
+}
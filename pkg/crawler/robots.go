@@ -0,0 +1,140 @@
+// pkg/crawler/robots.go
+
+package crawler
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// robotsRule es una directiva Allow/Disallow de robots.txt
+type robotsRule struct {
+	allow   bool
+	path    string
+	pattern *regexp.Regexp
+}
+
+// Robots contiene las reglas de robots.txt aplicables a nuestro user agent
+type Robots struct {
+	rules    []robotsRule
+	Sitemaps []string
+}
+
+// ParseRobots interpreta un robots.txt y se queda con las reglas del grupo que
+// mejor encaja con el user agent indicado, o con las del grupo "*"
+func ParseRobots(r io.Reader, userAgent string) (*Robots, error) {
+	robots := &Robots{}
+	agent := productToken(userAgent)
+
+	groups := make(map[string][]robotsRule)
+	var current []string
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !lastWasAgent {
+				current = nil
+			}
+			current = append(current, productToken(value))
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", path: value, pattern: compileRobotsPattern(value)}
+			for _, ua := range current {
+				groups[ua] = append(groups[ua], rule)
+			}
+		case "sitemap":
+			robots.Sitemaps = append(robots.Sitemaps, value)
+		default:
+			lastWasAgent = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Como indica el RFC 9309, el grupo se elige comparando el product token sin distinguir
+	// mayúsculas; si varios encajan gana el más específico y, si ninguno, el grupo "*"
+	best := ""
+	for ua := range groups {
+		if ua != "*" && ua != "" && matchesAgent(agent, ua) && len(ua) > len(best) {
+			best = ua
+		}
+	}
+	if best == "" {
+		best = "*"
+	}
+	robots.rules = groups[best]
+
+	return robots, nil
+}
+
+// productToken devuelve el nombre del producto de un user agent ("FreeRangeTesters/1.0 E2E"
+// → "freerangetesters"), en minúsculas
+func productToken(userAgent string) string {
+	token, _, _ := strings.Cut(strings.TrimSpace(userAgent), " ")
+	token, _, _ = strings.Cut(token, "/")
+	return strings.ToLower(token)
+}
+
+// matchesAgent indica si el user agent de un grupo se aplica a nuestro product token: el
+// mismo nombre o uno más general del que el nuestro es una variante ("googlebot" para
+// "googlebot-news")
+func matchesAgent(agent, ua string) bool {
+	return agent == ua || strings.HasPrefix(agent, ua+"-")
+}
+
+// Allowed indica si la ruta se puede rastrear; gana la regla más larga que coincida
+func (r *Robots) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		// En caso de empate entre Allow y Disallow prevalece Allow
+		if len(rule.path) > longest || (len(rule.path) == longest && rule.allow) {
+			longest = len(rule.path)
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// compileRobotsPattern convierte una ruta de robots.txt con comodines * y $ en una expresión regular
+func compileRobotsPattern(path string) *regexp.Regexp {
+	anchored := strings.HasSuffix(path, "$")
+	path = strings.TrimSuffix(path, "$")
+
+	parts := strings.Split(path, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
@@ -0,0 +1,40 @@
+// pkg/crawler/sitemap.go
+
+package crawler
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// sitemapDocument admite tanto <urlset> como <sitemapindex>
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// parseSitemap devuelve las URLs de páginas y de sitemaps anidados
+func parseSitemap(r io.Reader) (pages []string, nested []string, err error) {
+	var doc sitemapDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	for _, u := range doc.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+	for _, s := range doc.Sitemaps {
+		if loc := strings.TrimSpace(s.Loc); loc != "" {
+			nested = append(nested, loc)
+		}
+	}
+	return pages, nested, nil
+}
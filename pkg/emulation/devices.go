@@ -0,0 +1,82 @@
+// pkg/emulation/devices.go
+
+// Package emulation define los perfiles de dispositivo, idioma, zona horaria,
+// geolocalización y condiciones de red que se aplican a las sesiones de
+// navegador de chromedp y Playwright.
+package emulation
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Device es un perfil de dispositivo: tamaño de pantalla, densidad, user agent y soporte táctil
+type Device struct {
+	Name              string
+	Width             int
+	Height            int
+	DeviceScaleFactor float64
+	Mobile            bool
+	Touch             bool
+	UserAgent         string
+}
+
+// Slug devuelve un identificador del dispositivo apto para nombres de fichero
+func (d Device) Slug() string {
+	return strings.ToLower(strings.NewReplacer(" ", "-", "(", "", ")", "").Replace(d.Name))
+}
+
+func (d Device) String() string {
+	return fmt.Sprintf("%s (%dx%d@%gx)", d.Name, d.Width, d.Height, d.DeviceScaleFactor)
+}
+
+const (
+	desktopUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36"
+	iPadUserAgent    = "Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	iPhoneUserAgent  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	androidUserAgent = "Mozilla/5.0 (Linux; Android 14; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Mobile Safari/537.36"
+	tabletUserAgent  = "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36"
+)
+
+// Perfiles de dispositivo predefinidos
+var (
+	DesktopFullHD = Device{Name: "Desktop 1920", Width: 1920, Height: 1080, DeviceScaleFactor: 1, UserAgent: desktopUserAgent}
+	DesktopLaptop = Device{Name: "Desktop 1366", Width: 1366, Height: 768, DeviceScaleFactor: 1, UserAgent: desktopUserAgent}
+	IPadPro11     = Device{Name: "iPad Pro 11", Width: 834, Height: 1194, DeviceScaleFactor: 2, Mobile: true, Touch: true, UserAgent: iPadUserAgent}
+	GalaxyTabS9   = Device{Name: "Galaxy Tab S9", Width: 800, Height: 1280, DeviceScaleFactor: 2, Mobile: true, Touch: true, UserAgent: tabletUserAgent}
+	IPhone13      = Device{Name: "iPhone 13", Width: 390, Height: 844, DeviceScaleFactor: 3, Mobile: true, Touch: true, UserAgent: iPhoneUserAgent}
+	Pixel7        = Device{Name: "Pixel 7", Width: 412, Height: 915, DeviceScaleFactor: 2.625, Mobile: true, Touch: true, UserAgent: androidUserAgent}
+)
+
+// DefaultMatrix contiene los dispositivos sobre los que se ejecutan las pruebas por defecto
+var DefaultMatrix = []Device{DesktopFullHD, DesktopLaptop, IPadPro11, GalaxyTabS9, IPhone13, Pixel7}
+
+// MatrixFromEnv devuelve los dispositivos indicados en la variable DEVICES (por slug,
+// separados por comas) o la matriz por defecto si no está definida
+func MatrixFromEnv() ([]Device, error) {
+	value := strings.TrimSpace(os.Getenv("DEVICES"))
+	if value == "" {
+		return DefaultMatrix, nil
+	}
+
+	var devices []Device
+	for _, slug := range strings.Split(value, ",") {
+		device, ok := DeviceBySlug(strings.TrimSpace(slug))
+		if !ok {
+			return nil, fmt.Errorf("unknown device %q", slug)
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// DeviceBySlug busca un dispositivo predefinido por su slug
+func DeviceBySlug(slug string) (Device, bool) {
+	for _, device := range DefaultMatrix {
+		if device.Slug() == strings.ToLower(slug) {
+			return device, true
+		}
+	}
+	return Device{}, false
+}
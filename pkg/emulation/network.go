@@ -0,0 +1,101 @@
+// pkg/emulation/network.go
+
+package emulation
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// NetworkProfile describe unas condiciones de red emuladas
+type NetworkProfile struct {
+	Name    string
+	Offline bool
+	Latency time.Duration
+	// DownloadKbps y UploadKbps son el ancho de banda en kilobits por segundo; 0 no limita
+	DownloadKbps float64
+	UploadKbps   float64
+}
+
+// Slug devuelve un identificador del perfil apto para variables de entorno
+func (n NetworkProfile) Slug() string {
+	return strings.ToLower(strings.ReplaceAll(n.Name, " ", "-"))
+}
+
+func (n NetworkProfile) String() string {
+	if n.Offline {
+		return n.Name
+	}
+	return fmt.Sprintf("%s (%s, %g/%g kbps)", n.Name, n.Latency, n.DownloadKbps, n.UploadKbps)
+}
+
+// downloadBytesPerSecond convierte el ancho de banda al formato del protocolo DevTools (-1 desactiva el límite)
+func (n NetworkProfile) downloadBytesPerSecond() float64 {
+	return kbpsToBytes(n.DownloadKbps)
+}
+
+func (n NetworkProfile) uploadBytesPerSecond() float64 {
+	return kbpsToBytes(n.UploadKbps)
+}
+
+func kbpsToBytes(kbps float64) float64 {
+	if kbps <= 0 {
+		return -1
+	}
+	return kbps * 1000 / 8
+}
+
+// Perfiles de red predefinidos, equivalentes a los de las DevTools de Chrome
+var (
+	NetworkOffline = NetworkProfile{Name: "Offline", Offline: true}
+	NetworkSlow3G  = NetworkProfile{Name: "Slow 3G", Latency: 2000 * time.Millisecond, DownloadKbps: 400, UploadKbps: 400}
+	NetworkFast3G  = NetworkProfile{Name: "Fast 3G", Latency: 563 * time.Millisecond, DownloadKbps: 1440, UploadKbps: 675}
+	Network4G      = NetworkProfile{Name: "4G", Latency: 170 * time.Millisecond, DownloadKbps: 9000, UploadKbps: 9000}
+)
+
+// NetworkProfiles contiene los perfiles de red predefinidos
+var NetworkProfiles = []NetworkProfile{NetworkOffline, NetworkSlow3G, NetworkFast3G, Network4G}
+
+// CustomNetwork crea un perfil de red con la latencia y el ancho de banda indicados
+func CustomNetwork(name string, latency time.Duration, downloadKbps, uploadKbps float64) NetworkProfile {
+	return NetworkProfile{Name: name, Latency: latency, DownloadKbps: downloadKbps, UploadKbps: uploadKbps}
+}
+
+// NetworkBySlug busca un perfil de red predefinido por su slug
+func NetworkBySlug(slug string) (NetworkProfile, bool) {
+	for _, profile := range NetworkProfiles {
+		if profile.Slug() == strings.ToLower(slug) {
+			return profile, true
+		}
+	}
+	return NetworkProfile{}, false
+}
+
+// Factores de ralentización de CPU habituales
+const (
+	CPUNoThrottling  = 1
+	CPUMidTierMobile = 4
+	CPULowEndMobile  = 6
+)
+
+// ThrottlingFromEnv aplica a la sesión el perfil de red de la variable NETWORK
+// (por slug, por ejemplo "slow-3g") y la ralentización de CPU de CPU_SLOWDOWN
+func ThrottlingFromEnv(session Session) (Session, error) {
+	if slug := strings.TrimSpace(os.Getenv("NETWORK")); slug != "" {
+		profile, ok := NetworkBySlug(slug)
+		if !ok {
+			return session, fmt.Errorf("unknown network profile %q", slug)
+		}
+		session.Network = &profile
+	}
+	if value := strings.TrimSpace(os.Getenv("CPU_SLOWDOWN")); value != "" {
+		var rate float64
+		if _, err := fmt.Sscanf(value, "%g", &rate); err != nil || rate < 1 {
+			return session, fmt.Errorf("invalid CPU slowdown %q", value)
+		}
+		session.CPUSlowdown = rate
+	}
+	return session, nil
+}
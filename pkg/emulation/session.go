@@ -0,0 +1,267 @@
+// pkg/emulation/session.go
+
+package emulation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"GoLang_FRT_E2E_Tests/pkg/storagestate"
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+)
+
+// Geolocation es una posición geográfica emulada
+type Geolocation struct {
+	Latitude  float64
+	Longitude float64
+	Accuracy  float64
+}
+
+// Ubicaciones predefinidas
+var (
+	MadridBarajas   = Geolocation{Latitude: 40.4719, Longitude: -3.5626, Accuracy: 50}
+	BarcelonaElPrat = Geolocation{Latitude: 41.2974, Longitude: 2.0833, Accuracy: 50}
+)
+
+// Session agrupa las opciones de emulación que se aplican a una sesión de navegador.
+// El valor cero no emula nada.
+type Session struct {
+	Device *Device
+	// Locale es la etiqueta BCP 47 del idioma (por ejemplo "es-ES"); se aplica a
+	// Accept-Language, navigator.language e Intl
+	Locale string
+	// Timezone es el identificador IANA de la zona horaria (por ejemplo "Europe/Madrid")
+	Timezone string
+	// Geolocation es la posición emulada; el permiso de geolocalización se concede de antemano
+	Geolocation *Geolocation
+	// Network son las condiciones de red emuladas
+	Network *NetworkProfile
+	// CPUSlowdown es el factor de ralentización de la CPU (1 sin ralentizar)
+	CPUSlowdown float64
+	// Storage son las cookies y el almacenamiento con los que arranca la sesión
+	Storage *storagestate.State
+}
+
+// Spain devuelve una sesión con idioma, zona horaria y posición de Madrid
+func Spain() Session {
+	location := MadridBarajas
+	return Session{Locale: "es-ES", Timezone: "Europe/Madrid", Geolocation: &location}
+}
+
+// IsZero indica si la sesión no emula nada
+func (s Session) IsZero() bool {
+	return s.Device == nil && s.Locale == "" && s.Timezone == "" && s.Geolocation == nil &&
+		s.Network == nil && s.CPUSlowdown <= 1 && s.Storage == nil
+}
+
+// Name devuelve una etiqueta legible de la sesión para los logs y el reporte
+func (s Session) Name() string {
+	var parts []string
+	if s.Device != nil {
+		parts = append(parts, s.Device.Name)
+	}
+	if s.Locale != "" {
+		parts = append(parts, s.Locale)
+	}
+	if s.Timezone != "" {
+		parts = append(parts, s.Timezone)
+	}
+	if s.Network != nil {
+		parts = append(parts, s.Network.Name)
+	}
+	if s.CPUSlowdown > 1 {
+		parts = append(parts, fmt.Sprintf("CPU %gx", s.CPUSlowdown))
+	}
+	if s.Storage != nil && s.Storage.Name != "" {
+		parts = append(parts, s.Storage.Name)
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// acceptLanguage genera la cabecera Accept-Language a partir del locale ("es-ES,es;q=0.9")
+func (s Session) acceptLanguage() string {
+	lang, _, found := strings.Cut(s.Locale, "-")
+	if !found {
+		return s.Locale
+	}
+	return s.Locale + "," + lang + ";q=0.9"
+}
+
+// ChromedpActions devuelve las acciones que configuran la sesión en chromedp;
+// deben ejecutarse antes de navegar
+func (s Session) ChromedpActions() chromedp.Tasks {
+	var tasks chromedp.Tasks
+	if d := s.Device; d != nil {
+		opts := []chromedp.EmulateViewportOption{chromedp.EmulateScale(d.DeviceScaleFactor)}
+		if d.Mobile {
+			opts = append(opts, chromedp.EmulateMobile)
+		}
+		if d.Touch {
+			opts = append(opts, chromedp.EmulateTouch)
+		}
+		tasks = append(tasks, chromedp.EmulateViewport(int64(d.Width), int64(d.Height), opts...))
+	}
+
+	userAgent := ""
+	if s.Device != nil {
+		userAgent = s.Device.UserAgent
+	}
+	if userAgent != "" || s.Locale != "" {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			// La sobrescritura de Accept-Language exige indicar también el user agent
+			ua := userAgent
+			if ua == "" {
+				_, _, _, current, _, err := browser.GetVersion().Do(ctx)
+				if err != nil {
+					return err
+				}
+				ua = current
+			}
+			override := emulation.SetUserAgentOverride(ua)
+			if s.Locale != "" {
+				override = override.WithAcceptLanguage(s.acceptLanguage())
+			}
+			return override.Do(ctx)
+		}))
+	}
+
+	if s.Locale != "" {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			return emulation.SetLocaleOverride().WithLocale(strings.ReplaceAll(s.Locale, "-", "_")).Do(ctx)
+		}))
+	}
+
+	if s.Timezone != "" {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			return emulation.SetTimezoneOverride(s.Timezone).Do(ctx)
+		}))
+	}
+
+	if g := s.Geolocation; g != nil {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			if err := browser.GrantPermissions([]browser.PermissionType{browser.PermissionTypeGeolocation}).Do(ctx); err != nil {
+				return err
+			}
+			return emulation.SetGeolocationOverride().
+				WithLatitude(g.Latitude).
+				WithLongitude(g.Longitude).
+				WithAccuracy(g.Accuracy).
+				Do(ctx)
+		}))
+	}
+
+	if n := s.Network; n != nil {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			if err := network.Enable().Do(ctx); err != nil {
+				return err
+			}
+			return network.EmulateNetworkConditions(n.Offline, float64(n.Latency.Milliseconds()),
+				n.downloadBytesPerSecond(), n.uploadBytesPerSecond()).Do(ctx)
+		}))
+	}
+
+	if s.CPUSlowdown > 1 {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			return emulation.SetCPUThrottlingRate(s.CPUSlowdown).Do(ctx)
+		}))
+	}
+
+	if s.Storage != nil {
+		tasks = append(tasks, s.Storage.Chromedp())
+	}
+	return tasks
+}
+
+// ApplyPlaywright aplica las opciones que Playwright no expone en el contexto: el
+// sessionStorage del estado guardado y, mediante una sesión CDP, la red limitada y
+// la CPU (estas dos solo están disponibles en Chromium)
+func (s Session) ApplyPlaywright(browserContext playwright.BrowserContext, page playwright.Page) error {
+	if s.Storage != nil {
+		if err := s.Storage.ApplyPlaywright(browserContext); err != nil {
+			return err
+		}
+	}
+
+	throttleNetwork := s.Network != nil && !s.Network.Offline
+	if !throttleNetwork && s.CPUSlowdown <= 1 {
+		return nil
+	}
+
+	cdp, err := browserContext.NewCDPSession(page)
+	if err != nil {
+		return err
+	}
+
+	if throttleNetwork {
+		if _, err := cdp.Send("Network.enable", map[string]interface{}{}); err != nil {
+			return err
+		}
+		_, err := cdp.Send("Network.emulateNetworkConditions", map[string]interface{}{
+			"offline":            false,
+			"latency":            s.Network.Latency.Milliseconds(),
+			"downloadThroughput": s.Network.downloadBytesPerSecond(),
+			"uploadThroughput":   s.Network.uploadBytesPerSecond(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if s.CPUSlowdown > 1 {
+		if _, err := cdp.Send("Emulation.setCPUThrottlingRate", map[string]interface{}{"rate": s.CPUSlowdown}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlaywrightOptions devuelve las opciones de contexto de Playwright para la sesión
+func (s Session) PlaywrightOptions() playwright.BrowserNewContextOptions {
+	var opts playwright.BrowserNewContextOptions
+	if s.Device != nil {
+		applyDevice(&opts, *s.Device)
+	}
+
+	if s.Locale != "" {
+		opts.Locale = playwright.String(s.Locale)
+		opts.ExtraHttpHeaders = map[string]string{"Accept-Language": s.acceptLanguage()}
+	}
+	if s.Timezone != "" {
+		opts.TimezoneId = playwright.String(s.Timezone)
+	}
+	if g := s.Geolocation; g != nil {
+		opts.Geolocation = &playwright.Geolocation{
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Accuracy:  playwright.Float(g.Accuracy),
+		}
+		opts.Permissions = append(opts.Permissions, "geolocation")
+	}
+	if s.Network != nil && s.Network.Offline {
+		opts.Offline = playwright.Bool(true)
+	}
+	if s.Storage != nil {
+		opts.StorageState = s.Storage.PlaywrightOptions()
+	}
+	return opts
+}
+
+// applyDevice construye las opciones de Playwright a partir de los campos del dispositivo,
+// los mismos que usa chromedp, para que ambos drivers emulen la misma pantalla
+func applyDevice(opts *playwright.BrowserNewContextOptions, d Device) {
+	opts.Viewport = &playwright.Size{Width: d.Width, Height: d.Height}
+	opts.Screen = &playwright.Size{Width: d.Width, Height: d.Height}
+	opts.DeviceScaleFactor = playwright.Float(d.DeviceScaleFactor)
+	opts.IsMobile = playwright.Bool(d.Mobile)
+	opts.HasTouch = playwright.Bool(d.Touch)
+	if d.UserAgent != "" {
+		opts.UserAgent = playwright.String(d.UserAgent)
+	}
+}
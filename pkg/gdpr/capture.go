@@ -0,0 +1,171 @@
+// pkg/gdpr/capture.go
+
+package gdpr
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/consent"
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"github.com/playwright-community/playwright-go"
+)
+
+// DefaultSettle es el tiempo que se deja a la página para lanzar sus peticiones diferidas
+const DefaultSettle = 3 * time.Second
+
+// Report es el resultado de la comprobación de una página
+type Report struct {
+	URL          string
+	Consent      consent.Result
+	Observations []Observation
+	Issues       []lint.Issue
+}
+
+// Check carga una página en un contexto limpio, observa la red y las cookies antes del
+// consentimiento, rechaza el banner, recarga y vuelve a observar
+type Check struct {
+	Trackers TrackerList
+	Consent  *consent.Manager
+	Settle   time.Duration
+}
+
+// NewCheck crea una comprobación con la lista de rastreadores por defecto
+func NewCheck() *Check {
+	return &Check{
+		Trackers: DefaultTrackers,
+		Consent:  consent.NewManager(),
+		Settle:   DefaultSettle,
+	}
+}
+
+// recorder acumula las peticiones observadas en el contexto del navegador
+type recorder struct {
+	mu       sync.Mutex
+	requests []Request
+}
+
+func (r *recorder) record(request playwright.Request) {
+	u, err := url.Parse(request.URL())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, Request{URL: request.URL(), Host: u.Hostname(), ResourceType: request.ResourceType()})
+}
+
+// take devuelve las peticiones acumuladas y vacía el registro
+func (r *recorder) take() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	requests := r.requests
+	r.requests = nil
+	return requests
+}
+
+// RunPlaywright ejecuta la comprobación sobre pageURL en un contexto nuevo del navegador
+func (c *Check) RunPlaywright(browser playwright.Browser, pageURL string, options playwright.BrowserNewContextOptions) (*Report, error) {
+	browserContext, err := browser.NewContext(options)
+	if err != nil {
+		return nil, err
+	}
+	defer browserContext.Close()
+
+	rec := &recorder{}
+	browserContext.OnRequest(rec.record)
+
+	page, err := browserContext.NewPage()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := page.Goto(pageURL); err != nil {
+		return nil, err
+	}
+	c.settle(page)
+
+	report := &Report{URL: pageURL}
+	before, err := c.observe(browserContext, PhaseBeforeConsent, rec)
+	if err != nil {
+		return nil, err
+	}
+	report.Observations = append(report.Observations, before)
+
+	manager := c.Consent
+	if manager == nil {
+		manager = consent.NewManager()
+	}
+	if report.Consent, err = manager.Playwright(page, consent.Reject()); err != nil {
+		return nil, err
+	}
+
+	// Tras rechazar se recarga para comprobar que la decisión se respeta en las siguientes cargas.
+	// Antes se borran las cookies no esenciales de la primera carga: si siguen apareciendo es que
+	// la página las vuelve a crear tras el rechazo y no que se arrastran de antes
+	if err := c.clearNonEssential(browserContext); err != nil {
+		return nil, err
+	}
+	if _, err := page.Reload(); err != nil {
+		return nil, err
+	}
+	c.settle(page)
+	after, err := c.observe(browserContext, PhaseAfterReject, rec)
+	if err != nil {
+		return nil, err
+	}
+	report.Observations = append(report.Observations, after)
+
+	report.Issues = Audit(pageURL, report.Observations, c.Trackers)
+	if !report.Consent.Shown() {
+		report.Issues = append(report.Issues, lint.Issue{
+			Rule:     "consent-banner",
+			Severity: lint.SeverityWarning,
+			Element:  pageURL,
+			Message:  "no se ha detectado ningún banner de consentimiento que rechazar",
+		})
+	}
+	return report, nil
+}
+
+// clearNonEssential borra del contexto las cookies no esenciales y conserva las demás, entre
+// ellas la que guarda el rechazo del consentimiento
+func (c *Check) clearNonEssential(browserContext playwright.BrowserContext) error {
+	cookies, err := browserContext.Cookies()
+	if err != nil {
+		return err
+	}
+	for _, cookie := range cookies {
+		if !c.Trackers.NonEssential(Cookie{Name: cookie.Name, Domain: cookie.Domain}) {
+			continue
+		}
+		if err := browserContext.ClearCookies(playwright.BrowserContextClearCookiesOptions{
+			Name:   cookie.Name,
+			Domain: cookie.Domain,
+			Path:   cookie.Path,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settle espera a que la red quede inactiva y deja un margen para las peticiones diferidas
+func (c *Check) settle(page playwright.Page) {
+	_ = page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{State: playwright.LoadStateNetworkidle})
+	time.Sleep(c.Settle)
+}
+
+// observe recoge las peticiones acumuladas desde la última observación y las cookies actuales
+func (c *Check) observe(browserContext playwright.BrowserContext, phase string, rec *recorder) (Observation, error) {
+	cookies, err := browserContext.Cookies()
+	if err != nil {
+		return Observation{}, err
+	}
+
+	observation := Observation{Phase: phase, Requests: rec.take()}
+	for _, cookie := range cookies {
+		observation.Cookies = append(observation.Cookies, Cookie{Name: cookie.Name, Domain: cookie.Domain})
+	}
+	return observation, nil
+}
@@ -0,0 +1,211 @@
+// pkg/gdpr/gdpr.go
+
+// Package gdpr comprueba que una página no rastrea al visitante antes de que dé su
+// consentimiento: registra las peticiones a terceros y las cookies antes de tocar el
+// banner y después de rechazarlo, y señala los rastreadores y las cookies no esenciales.
+package gdpr
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"golang.org/x/net/publicsuffix"
+	"gopkg.in/yaml.v3"
+)
+
+// Fases en las que se observa la página
+const (
+	PhaseBeforeConsent = "before-consent"
+	PhaseAfterReject   = "after-reject"
+)
+
+// TrackerList define los dominios de rastreo y las cookies no esenciales conocidas
+type TrackerList struct {
+	// Domains son dominios de rastreo; también casan sus subdominios
+	Domains []string `yaml:"domains"`
+	// Cookies son patrones (path.Match) de nombres de cookies no esenciales
+	Cookies []string `yaml:"cookies"`
+	// Essential son patrones de cookies que se permiten aunque casen con Cookies o vengan de un rastreador
+	Essential []string `yaml:"essential"`
+}
+
+// DefaultTrackers contiene los rastreadores y cookies de analítica y publicidad más habituales
+var DefaultTrackers = TrackerList{
+	Domains: []string{
+		"google-analytics.com", "googletagmanager.com", "doubleclick.net", "googleadservices.com",
+		"googlesyndication.com", "facebook.net", "facebook.com", "connect.facebook.net",
+		"hotjar.com", "bat.bing.com", "clarity.ms", "criteo.com", "criteo.net", "taboola.com",
+		"outbrain.com", "analytics.tiktok.com", "snap.licdn.com", "px.ads.linkedin.com",
+		"adnxs.com", "quantserve.com", "scorecardresearch.com", "collect.tealiumiq.com",
+		"adsrvr.org", "amazon-adsystem.com", "yandex.ru", "mouseflow.com",
+	},
+	Cookies: []string{
+		"_ga", "_ga_*", "_gid", "_gat*", "_gcl_*", "_fbp", "_fbc", "fr", "_hj*", "IDE", "MUID",
+		"_uetsid", "_uetvid", "_clck", "_clsk", "_tt_*", "_ttp", "li_*", "bcookie", "cto_*",
+		"utag_main", "_pin_unauth", "__qca",
+	},
+	Essential: []string{
+		"OptanonConsent", "OptanonAlertBoxClosed", "CookieConsent", "didomi_token",
+		"CONSENTMGR", "euconsent-v2",
+	},
+}
+
+// LoadTrackerList lee la lista de rastreadores desde un fichero YAML
+func LoadTrackerList(file string) (TrackerList, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return TrackerList{}, err
+	}
+	var list TrackerList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return TrackerList{}, fmt.Errorf("lista de rastreadores inválida: %w", err)
+	}
+	return list, nil
+}
+
+// TrackersFromEnv devuelve la lista por defecto ampliada, si GDPR_TRACKERS apunta a un
+// fichero YAML, con los dominios y cookies definidos en él
+func TrackersFromEnv() (TrackerList, error) {
+	file := strings.TrimSpace(os.Getenv("GDPR_TRACKERS"))
+	if file == "" {
+		return DefaultTrackers, nil
+	}
+	extra, err := LoadTrackerList(file)
+	if err != nil {
+		return TrackerList{}, err
+	}
+	return DefaultTrackers.Merge(extra), nil
+}
+
+// Merge devuelve la unión de las dos listas
+func (l TrackerList) Merge(other TrackerList) TrackerList {
+	return TrackerList{
+		Domains:   append(append([]string(nil), l.Domains...), other.Domains...),
+		Cookies:   append(append([]string(nil), l.Cookies...), other.Cookies...),
+		Essential: append(append([]string(nil), l.Essential...), other.Essential...),
+	}
+}
+
+// Tracker devuelve el dominio de rastreo al que pertenece host, o "" si no es un rastreador
+func (l TrackerList) Tracker(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, domain := range l.Domains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return domain
+		}
+	}
+	return ""
+}
+
+// NonEssential indica si la cookie es de analítica o publicidad según su nombre o su dominio
+func (l TrackerList) NonEssential(cookie Cookie) bool {
+	if matchAny(l.Essential, cookie.Name) {
+		return false
+	}
+	return matchAny(l.Cookies, cookie.Name) || l.Tracker(strings.TrimPrefix(cookie.Domain, ".")) != ""
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Request es una petición observada durante la carga de la página
+type Request struct {
+	URL          string
+	Host         string
+	ResourceType string
+}
+
+// Cookie es una cookie presente en el navegador
+type Cookie struct {
+	Name   string
+	Domain string
+}
+
+// Observation recoge lo observado en una fase
+type Observation struct {
+	Phase    string
+	Requests []Request
+	Cookies  []Cookie
+}
+
+// Site devuelve el dominio registrable (eTLD+1) de un host; para IPs y hosts locales devuelve el host
+func Site(host string) string {
+	host = strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host
+	}
+	site, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return site
+}
+
+// Audit compara lo observado antes del consentimiento y tras rechazarlo con la lista de
+// rastreadores y devuelve los incumplimientos encontrados
+func Audit(pageURL string, observations []Observation, list TrackerList) []lint.Issue {
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		return []lint.Issue{{Rule: "gdpr-page-url", Severity: lint.SeverityError, Element: pageURL, Message: err.Error()}}
+	}
+	firstParty := Site(page.Hostname())
+
+	var issues []lint.Issue
+	for _, observation := range observations {
+		seen := make(map[string]bool)
+		for _, request := range observation.Requests {
+			if Site(request.Host) == firstParty {
+				continue
+			}
+			if seen[request.Host] {
+				continue
+			}
+			seen[request.Host] = true
+			tracker := list.Tracker(request.Host)
+
+			if tracker != "" {
+				issues = append(issues, lint.Issue{
+					Rule:     "tracker-" + observation.Phase,
+					Severity: lint.SeverityError,
+					Element:  request.URL,
+					Message:  fmt.Sprintf("petición al rastreador %s sin consentimiento (%s)", tracker, observation.Phase),
+				})
+				continue
+			}
+			issues = append(issues, lint.Issue{
+				Rule:     "third-party-" + observation.Phase,
+				Severity: lint.SeverityWarning,
+				Element:  request.URL,
+				Message:  fmt.Sprintf("petición a terceros %s sin consentimiento (%s)", request.Host, observation.Phase),
+			})
+		}
+
+		for _, cookie := range observation.Cookies {
+			if !list.NonEssential(cookie) {
+				continue
+			}
+			issues = append(issues, lint.Issue{
+				Rule:     "cookie-" + observation.Phase,
+				Severity: lint.SeverityError,
+				Element:  cookie.Name + "@" + cookie.Domain,
+				Message:  fmt.Sprintf("cookie no esencial %s presente sin consentimiento (%s)", cookie.Name, observation.Phase),
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,234 @@
+// pkg/links/checker.go
+
+package links
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Result contiene el resultado de verificar un enlace
+type Result struct {
+	Link       Link
+	Method     string
+	StatusCode int
+	Redirects  []string
+	Duration   time.Duration
+	Err        error
+	Slow       bool
+}
+
+// Broken indica si el enlace no es accesible
+func (r Result) Broken() bool {
+	return r.Err != nil || r.StatusCode >= 400
+}
+
+func (r Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s -> error: %v", r.Link.Resolved, r.Err)
+	}
+	return fmt.Sprintf("%s -> %d (%s, %d redirects, %s)", r.Link.Resolved, r.StatusCode, r.Method, len(r.Redirects), r.Duration.Round(time.Millisecond))
+}
+
+// Report agrupa los resultados de los enlaces de una página origen
+type Report struct {
+	Source  string
+	Results []Result
+	Skipped []Link
+}
+
+// Broken devuelve los enlaces rotos
+func (r *Report) Broken() []Result {
+	return r.filter(Result.Broken)
+}
+
+// Redirected devuelve los enlaces que han pasado por redirecciones
+func (r *Report) Redirected() []Result {
+	return r.filter(func(res Result) bool { return len(res.Redirects) > 0 })
+}
+
+// SlowResults devuelve los enlaces que han superado el umbral de lentitud
+func (r *Report) SlowResults() []Result {
+	return r.filter(func(res Result) bool { return res.Slow })
+}
+
+func (r *Report) filter(keep func(Result) bool) []Result {
+	var results []Result
+	for _, res := range r.Results {
+		if keep(res) {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// Checker verifica la accesibilidad de enlaces de forma concurrente. También se puede crear
+// como literal; NewChecker solo rellena valores por defecto.
+type Checker struct {
+	Concurrency   int
+	PerHostDelay  time.Duration
+	SlowThreshold time.Duration
+	UserAgent     string
+	client        *http.Client
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+// NewChecker crea un nuevo Checker con valores por defecto
+func NewChecker() *Checker {
+	return &Checker{
+		Concurrency:   8,
+		PerHostDelay:  200 * time.Millisecond,
+		SlowThreshold: 3 * time.Second,
+		UserAgent:     "FreeRangeTesters E2E Tests",
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// SetClient sustituye el cliente HTTP utilizado por el Checker
+func (c *Checker) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// Check verifica los enlaces encontrados en la página origen
+func (c *Checker) Check(ctx context.Context, source string, raws []string) (*Report, error) {
+	classified, err := ClassifyAll(source, raws)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Source: source}
+	seen := make(map[string]bool)
+	var pending []Link
+	for _, link := range classified {
+		if !link.Checkable() {
+			report.Skipped = append(report.Skipped, link)
+			continue
+		}
+		if seen[link.Resolved] {
+			continue
+		}
+		seen[link.Resolved] = true
+		pending = append(pending, link)
+	}
+
+	concurrency := c.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(pending))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, link := range pending {
+		wg.Add(1)
+		go func(i int, link Link) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = c.checkLink(ctx, link)
+		}(i, link)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Link.Resolved < results[j].Link.Resolved })
+	report.Results = results
+	return report, nil
+}
+
+// checkLink verifica un enlace con HEAD y, si el servidor no lo admite, con GET. Algunos
+// servidores responden 403 solo a HEAD: se reintenta con GET, pero si GET también falla se
+// informa de la respuesta original para no ocultar un problema de acceso real.
+func (c *Checker) checkLink(ctx context.Context, link Link) Result {
+	result := c.request(ctx, http.MethodHead, link)
+	switch {
+	case result.Err != nil || headUnsupported(result.StatusCode):
+		result = c.request(ctx, http.MethodGet, link)
+	case result.StatusCode == http.StatusForbidden:
+		if retry := c.request(ctx, http.MethodGet, link); !retry.Broken() {
+			result = retry
+		}
+	}
+	result.Slow = c.SlowThreshold > 0 && result.Duration > c.SlowThreshold
+	return result
+}
+
+func (c *Checker) request(ctx context.Context, method string, link Link) Result {
+	result := Result{Link: link, Method: method}
+
+	req, err := http.NewRequestWithContext(ctx, method, link.Resolved, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	if err := c.waitForHost(ctx, req.URL); err != nil {
+		result.Err = err
+		return result
+	}
+
+	client := c.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Redirects = redirectChain(resp)
+	return result
+}
+
+// waitForHost espera hasta que se pueda volver a consultar el host
+func (c *Checker) waitForHost(ctx context.Context, u *url.URL) error {
+	if c.PerHostDelay <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	if c.nextSlot == nil {
+		c.nextSlot = make(map[string]time.Time)
+	}
+	now := time.Now()
+	slot := c.nextSlot[u.Host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[u.Host] = slot.Add(c.PerHostDelay)
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(slot)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// redirectChain reconstruye las URLs intermedias por las que ha pasado la petición
+func redirectChain(resp *http.Response) []string {
+	var chain []string
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		chain = append([]string{req.Response.Request.URL.String()}, chain...)
+	}
+	return chain
+}
+
+func headUnsupported(status int) bool {
+	return status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented
+}
@@ -0,0 +1,23 @@
+// pkg/links/extract.go
+
+package links
+
+import "github.com/PuerkitoBio/goquery"
+
+// Extract obtiene los valores href y src únicos del documento en orden de aparición
+func Extract(doc *goquery.Document) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	// Buscar enlaces en diferentes elementos
+	doc.Find("a[href], link[href], [src]").Each(func(_ int, s *goquery.Selection) {
+		for _, attr := range []string{"href", "src"} {
+			if value, exists := s.Attr(attr); exists && !seen[value] {
+				seen[value] = true
+				result = append(result, value)
+			}
+		}
+	})
+
+	return result
+}
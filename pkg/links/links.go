@@ -0,0 +1,108 @@
+// pkg/links/links.go
+
+// Package links resuelve, clasifica y verifica los enlaces extraídos de las páginas
+package links
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Kind indica el tipo de enlace
+type Kind string
+
+const (
+	KindInternal   Kind = "internal"
+	KindExternal   Kind = "external"
+	KindAsset      Kind = "asset"
+	KindAnchor     Kind = "anchor"
+	KindContact    Kind = "contact"
+	KindJavaScript Kind = "javascript"
+	KindInvalid    Kind = "invalid"
+)
+
+// assetExtensions contiene las extensiones que se consideran recursos estáticos
+var assetExtensions = map[string]bool{
+	".css": true, ".js": true, ".mjs": true, ".json": true, ".xml": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true, ".avif": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+	".mp4": true, ".webm": true, ".mp3": true, ".pdf": true,
+}
+
+// Link representa un enlace resuelto contra la URL de la página
+type Link struct {
+	Raw      string
+	Resolved string
+	Kind     Kind
+}
+
+// Checkable indica si el enlace se puede verificar por HTTP
+func (l Link) Checkable() bool {
+	return l.Kind == KindInternal || l.Kind == KindExternal || l.Kind == KindAsset
+}
+
+// Classify resuelve el enlace contra la URL base y determina su tipo
+func Classify(base *url.URL, raw string) Link {
+	link := Link{Raw: raw}
+	trimmed := strings.TrimSpace(raw)
+	lower := strings.ToLower(trimmed)
+
+	switch {
+	case strings.HasPrefix(lower, "javascript:"):
+		link.Kind = KindJavaScript
+		return link
+	case strings.HasPrefix(lower, "mailto:"), strings.HasPrefix(lower, "tel:"):
+		link.Kind = KindContact
+		link.Resolved = trimmed
+		return link
+	case strings.HasPrefix(trimmed, "#"):
+		link.Kind = KindAnchor
+		link.Resolved = base.ResolveReference(&url.URL{Fragment: strings.TrimPrefix(trimmed, "#")}).String()
+		return link
+	}
+
+	ref, err := url.Parse(trimmed)
+	if err != nil {
+		link.Kind = KindInvalid
+		return link
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		link.Kind = KindInvalid
+		link.Resolved = resolved.String()
+		return link
+	}
+	resolved.Fragment = ""
+	link.Resolved = resolved.String()
+
+	switch {
+	case assetExtensions[strings.ToLower(path.Ext(resolved.Path))]:
+		link.Kind = KindAsset
+	case SameSite(base, resolved):
+		link.Kind = KindInternal
+	default:
+		link.Kind = KindExternal
+	}
+	return link
+}
+
+// ClassifyAll resuelve y clasifica una lista de enlaces contra la URL de la página
+func ClassifyAll(pageURL string, raws []string) ([]Link, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Link, 0, len(raws))
+	for _, raw := range raws {
+		result = append(result, Classify(base, raw))
+	}
+	return result, nil
+}
+
+// SameSite indica si dos URLs pertenecen al mismo sitio, ignorando el prefijo www.
+func SameSite(a, b *url.URL) bool {
+	return strings.TrimPrefix(strings.ToLower(a.Hostname()), "www.") ==
+		strings.TrimPrefix(strings.ToLower(b.Hostname()), "www.")
+}
@@ -0,0 +1,266 @@
+// pkg/lint/lint.go
+
+// Package lint contiene comprobaciones estáticas de accesibilidad y conformidad
+// HTML que se ejecutan sobre un documento goquery, sin necesidad de navegador.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Severity indica la gravedad de un problema encontrado
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue representa un problema encontrado por una regla
+type Issue struct {
+	Rule     string
+	Severity Severity
+	Element  string
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Element == "" {
+		return fmt.Sprintf("[%s] %s: %s", i.Severity, i.Rule, i.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s (%s)", i.Severity, i.Rule, i.Message, i.Element)
+}
+
+// Rule es una comprobación que se ejecuta sobre el documento
+type Rule struct {
+	Name  string
+	Check func(doc *goquery.Document) []Issue
+}
+
+// DefaultRules contiene las reglas que se ejecutan por defecto
+var DefaultRules = []Rule{
+	{Name: "document-title", Check: checkTitle},
+	{Name: "html-lang", Check: checkHTMLLang},
+	{Name: "heading-order", Check: checkHeadingOrder},
+	{Name: "duplicate-id", Check: checkDuplicateIDs},
+	{Name: "empty-link", Check: checkEmptyLinks},
+	{Name: "img-alt", Check: checkImageAlt},
+	{Name: "form-label", Check: checkFormLabels},
+}
+
+// Check ejecuta las reglas por defecto sobre el documento
+func Check(doc *goquery.Document) []Issue {
+	return Run(doc, DefaultRules...)
+}
+
+// Run ejecuta las reglas indicadas sobre el documento
+func Run(doc *goquery.Document, rules ...Rule) []Issue {
+	var issues []Issue
+	for _, rule := range rules {
+		issues = append(issues, rule.Check(doc)...)
+	}
+	return issues
+}
+
+// Errors filtra los problemas de severidad error
+func Errors(issues []Issue) []Issue {
+	var errs []Issue
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+// checkTitle verifica que el documento tenga un <title> no vacío
+func checkTitle(doc *goquery.Document) []Issue {
+	if strings.TrimSpace(doc.Find("title").First().Text()) == "" {
+		return []Issue{{
+			Rule:     "document-title",
+			Severity: SeverityError,
+			Element:  "title",
+			Message:  "Falta el título o está vacío",
+		}}
+	}
+	return nil
+}
+
+// checkHTMLLang verifica que <html> declare el idioma del documento
+func checkHTMLLang(doc *goquery.Document) []Issue {
+	lang, _ := doc.Find("html").First().Attr("lang")
+	if strings.TrimSpace(lang) == "" {
+		return []Issue{{
+			Rule:     "html-lang",
+			Severity: SeverityError,
+			Element:  "html",
+			Message:  "Falta el atributo lang",
+		}}
+	}
+	return nil
+}
+
+// checkHeadingOrder verifica que los encabezados no se salten niveles
+func checkHeadingOrder(doc *goquery.Document) []Issue {
+	var issues []Issue
+	previous := 0
+	doc.Find("h1, h2, h3, h4, h5, h6").Each(func(_ int, s *goquery.Selection) {
+		level, _ := strconv.Atoi(strings.TrimPrefix(goquery.NodeName(s), "h"))
+		if previous == 0 && level != 1 {
+			issues = append(issues, Issue{
+				Rule:     "heading-order",
+				Severity: SeverityWarning,
+				Element:  describe(s),
+				Message:  fmt.Sprintf("El primer encabezado es h%d, se esperaba h1", level),
+			})
+		} else if previous != 0 && level > previous+1 {
+			issues = append(issues, Issue{
+				Rule:     "heading-order",
+				Severity: SeverityWarning,
+				Element:  describe(s),
+				Message:  fmt.Sprintf("Nivel de encabezado saltado: h%d después de h%d", level, previous),
+			})
+		}
+		previous = level
+	})
+	return issues
+}
+
+// checkDuplicateIDs verifica que no haya atributos id repetidos
+func checkDuplicateIDs(doc *goquery.Document) []Issue {
+	counts := make(map[string]int)
+	doc.Find("[id]").Each(func(_ int, s *goquery.Selection) {
+		id, _ := s.Attr("id")
+		if id = strings.TrimSpace(id); id != "" {
+			counts[id]++
+		}
+	})
+
+	ids := make([]string, 0, len(counts))
+	for id, count := range counts {
+		if count > 1 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	issues := make([]Issue, 0, len(ids))
+	for _, id := range ids {
+		issues = append(issues, Issue{
+			Rule:     "duplicate-id",
+			Severity: SeverityError,
+			Element:  "#" + id,
+			Message:  fmt.Sprintf("Id duplicado usado %d veces", counts[id]),
+		})
+	}
+	return issues
+}
+
+// checkEmptyLinks verifica que todos los enlaces tengan un nombre accesible
+func checkEmptyLinks(doc *goquery.Document) []Issue {
+	var issues []Issue
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		if hasAccessibleName(s) || strings.TrimSpace(s.Text()) != "" {
+			return
+		}
+		hasAltImage := false
+		s.Find("img[alt]").Each(func(_ int, img *goquery.Selection) {
+			if alt, _ := img.Attr("alt"); strings.TrimSpace(alt) != "" {
+				hasAltImage = true
+			}
+		})
+		if hasAltImage {
+			return
+		}
+		issues = append(issues, Issue{
+			Rule:     "empty-link",
+			Severity: SeverityError,
+			Element:  describe(s),
+			Message:  "El enlace no tiene nombre accesible",
+		})
+	})
+	return issues
+}
+
+// checkImageAlt verifica que las imágenes tengan atributo alt
+func checkImageAlt(doc *goquery.Document) []Issue {
+	var issues []Issue
+	doc.Find("img:not([alt])").Each(func(_ int, s *goquery.Selection) {
+		if role, _ := s.Attr("role"); role == "presentation" || role == "none" {
+			return
+		}
+		if _, hidden := s.Attr("aria-hidden"); hidden {
+			return
+		}
+		issues = append(issues, Issue{
+			Rule:     "img-alt",
+			Severity: SeverityError,
+			Element:  describe(s),
+			Message:  "La imagen no tiene atributo alt",
+		})
+	})
+	return issues
+}
+
+// checkFormLabels verifica que los campos de formulario tengan etiqueta
+func checkFormLabels(doc *goquery.Document) []Issue {
+	labelled := make(map[string]bool)
+	doc.Find("label[for]").Each(func(_ int, s *goquery.Selection) {
+		id, _ := s.Attr("for")
+		labelled[id] = true
+	})
+
+	var issues []Issue
+	doc.Find("input, select, textarea").Each(func(_ int, s *goquery.Selection) {
+		switch inputType, _ := s.Attr("type"); strings.ToLower(inputType) {
+		case "hidden", "submit", "button", "reset", "image":
+			return
+		}
+		if id, ok := s.Attr("id"); ok && labelled[id] {
+			return
+		}
+		if s.ParentsFiltered("label").Length() > 0 || hasAccessibleName(s) {
+			return
+		}
+		issues = append(issues, Issue{
+			Rule:     "form-label",
+			Severity: SeverityError,
+			Element:  describe(s),
+			Message:  "El campo del formulario no tiene etiqueta",
+		})
+	})
+	return issues
+}
+
+// hasAccessibleName indica si el elemento tiene nombre accesible por atributos ARIA o title
+func hasAccessibleName(s *goquery.Selection) bool {
+	for _, attr := range []string{"aria-label", "aria-labelledby", "title"} {
+		if value, ok := s.Attr(attr); ok && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// describe genera una descripción corta del elemento para los reportes
+func describe(s *goquery.Selection) string {
+	desc := goquery.NodeName(s)
+	if id, ok := s.Attr("id"); ok && id != "" {
+		desc += "#" + id
+	}
+	if class, ok := s.Attr("class"); ok && class != "" {
+		desc += "." + strings.Join(strings.Fields(class), ".")
+	}
+	for _, attr := range []string{"href", "src", "name"} {
+		if value, ok := s.Attr(attr); ok {
+			desc += fmt.Sprintf("[%s=%q]", attr, value)
+			break
+		}
+	}
+	return desc
+}
@@ -0,0 +1,293 @@
+// pkg/pages/avis_booking.go
+package pages
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+    "sync"
+
+    "github.com/playwright-community/playwright-go"
+)
+
+// Selectores y etiquetas del embudo de reserva
+const (
+    extraItemSelector   = ".extra, .protection, .extras__item"
+    summaryItemSelector = ".summary__item, .price-breakdown li"
+    totalSelector       = ".total-price, .summary__total"
+    continueLabel       = "Continuar"
+    firstNameLabel      = "Nombre"
+    lastNameLabel       = "Apellidos"
+    emailLabel          = "Correo electrónico"
+    phoneLabel          = "Teléfono"
+)
+
+// finalActionPattern reconoce los controles que enviarían el pago o confirmarían la reserva
+var finalActionPattern = regexp.MustCompile(`(?i)\b(pagar|pague|pago|confirmar|confirme|finalizar|completar reserva|reservar ahora|pay|confirm|book now|complete booking|place order)\b`)
+
+// paymentEndpointPattern reconoce las peticiones de pago o confirmación que el guardia bloquea
+var paymentEndpointPattern = regexp.MustCompile(`(?i)(payment|pago|checkout/confirm|booking/confirm|reservation/confirm)`)
+
+// IsFinalAction indica si el texto de un control corresponde a pagar o confirmar la reserva
+func IsFinalAction(text string) bool {
+    return finalActionPattern.MatchString(foldText(text))
+}
+
+// PaymentGuardError indica que se ha impedido pulsar un control de pago o confirmación
+type PaymentGuardError struct {
+    Control string
+}
+
+func (e *PaymentGuardError) Error() string {
+    return fmt.Sprintf("refusing to activate final payment control %q", e.Control)
+}
+
+// PaymentGuard impide que los tests lleguen a enviar un pago: se niega a pulsar controles
+// finales y aborta cualquier petición que no sea GET a los endpoints de pago.
+type PaymentGuard struct {
+    mu      sync.Mutex
+    blocked []string
+}
+
+// newPaymentGuard instala el bloqueo de peticiones de pago en la página
+func newPaymentGuard(driver playwright.Page) (*PaymentGuard, error) {
+    guard := &PaymentGuard{}
+    err := driver.Route(paymentEndpointPattern, func(route playwright.Route) {
+        request := route.Request()
+        if request.Method() == "GET" {
+            _ = route.Continue()
+            return
+        }
+        guard.mu.Lock()
+        guard.blocked = append(guard.blocked, request.Method()+" "+request.URL())
+        guard.mu.Unlock()
+        _ = route.Abort("blockedbyclient")
+    })
+    if err != nil {
+        return nil, &PageError{"Error installing payment guard", err}
+    }
+    return guard, nil
+}
+
+// Blocked devuelve las peticiones de pago que se han abortado
+func (g *PaymentGuard) Blocked() []string {
+    g.mu.Lock()
+    defer g.mu.Unlock()
+    return append([]string(nil), g.blocked...)
+}
+
+// click pulsa el control solo si su texto no corresponde a una acción final
+func (g *PaymentGuard) click(control playwright.Locator) error {
+    text, err := control.InnerText()
+    if err != nil {
+        return err
+    }
+    if value, _ := control.GetAttribute("value"); value != "" {
+        text += " " + value
+    }
+    if IsFinalAction(text) {
+        return &PaymentGuardError{Control: strings.Join(strings.Fields(text), " ")}
+    }
+    return control.Click()
+}
+
+// LineItem representa una línea de precio del embudo de reserva
+type LineItem struct {
+    Name     string
+    Price    Price
+    Selected bool
+}
+
+// ParseLineItem separa el concepto y el importe de una línea como "Silla infantil 45,00 €"
+func ParseLineItem(text string) (LineItem, error) {
+    text = strings.Join(strings.Fields(text), " ")
+    matches := pricePattern.FindAllStringIndex(text, -1)
+    for i := len(matches) - 1; i >= 0; i-- {
+        candidate := text[matches[i][0]:matches[i][1]]
+        price, err := ParsePrice(candidate)
+        if err != nil {
+            continue
+        }
+        name := strings.TrimSpace(text[:matches[i][0]] + text[matches[i][1]:])
+        return LineItem{Name: strings.TrimRight(name, " :·-"), Price: price}, nil
+    }
+    return LineItem{}, &PageError{fmt.Sprintf("No price found in line %q", text), nil}
+}
+
+// SumLineItems suma los importes de las líneas en la moneda de la primera
+func SumLineItems(items []LineItem) Price {
+    var total Price
+    for _, item := range items {
+        if total.Currency == "" {
+            total.Currency = item.Price.Currency
+        }
+        total.Amount += item.Price.Amount
+    }
+    return total
+}
+
+// readLineItems interpreta las líneas de precio que casan con el selector
+func readLineItems(driver playwright.Page, selector string) ([]LineItem, error) {
+    rows, err := driver.Locator(selector).All()
+    if err != nil {
+        return nil, err
+    }
+
+    var items []LineItem
+    for _, row := range rows {
+        text, err := row.InnerText()
+        if err != nil {
+            return nil, err
+        }
+        item, err := ParseLineItem(text)
+        if err != nil {
+            // Las líneas incluidas sin coste no muestran importe
+            item = LineItem{Name: strings.Join(strings.Fields(text), " ")}
+        }
+        checkbox := row.Locator("input[type='checkbox']")
+        if count, _ := checkbox.Count(); count > 0 {
+            item.Selected, _ = checkbox.First().IsChecked()
+        }
+        items = append(items, item)
+    }
+    return items, nil
+}
+
+// readTotal interpreta el importe total que muestra el paso actual
+func readTotal(driver playwright.Page) (Price, error) {
+    text, err := driver.Locator(totalSelector).First().InnerText()
+    if err != nil {
+        return Price{}, &PageError{"Total not found", err}
+    }
+    return ParsePrice(text)
+}
+
+// continueTo pulsa "Continuar" a través del guardia
+func continueTo(driver playwright.Page, guard *PaymentGuard) error {
+    button := driver.GetByRole("button", playwright.PageGetByRoleOptions{Name: continueLabel}).First()
+    if err := guard.click(button); err != nil {
+        return err
+    }
+    return driver.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+        State: playwright.LoadStateNetworkidle,
+    })
+}
+
+// AvisExtrasPage representa el paso de extras y protecciones
+type AvisExtrasPage struct {
+    driver playwright.Page
+    guard  *PaymentGuard
+}
+
+// Guard devuelve el guardia de pago del embudo
+func (ep *AvisExtrasPage) Guard() *PaymentGuard {
+    return ep.guard
+}
+
+// Extras devuelve los extras y protecciones ofrecidos, con su precio y si están seleccionados
+func (ep *AvisExtrasPage) Extras() ([]LineItem, error) {
+    return readLineItems(ep.driver, extraItemSelector)
+}
+
+// SetExtra selecciona o quita el extra cuyo nombre coincide con el indicado
+func (ep *AvisExtrasPage) SetExtra(name string, selected bool) error {
+    extras, err := ep.Extras()
+    if err != nil {
+        return err
+    }
+
+    names := make([]string, len(extras))
+    for i, extra := range extras {
+        names[i] = extra.Name
+    }
+    index, err := MatchSuggestion(names, name)
+    if err != nil {
+        return err
+    }
+
+    checkbox := ep.driver.Locator(extraItemSelector).Nth(index).Locator("input[type='checkbox']").First()
+    return checkbox.SetChecked(selected)
+}
+
+// Total devuelve el importe total con los extras seleccionados
+func (ep *AvisExtrasPage) Total() (Price, error) {
+    return readTotal(ep.driver)
+}
+
+// Continue avanza al paso de datos del conductor
+func (ep *AvisExtrasPage) Continue() (*AvisDriverDetailsPage, error) {
+    if err := continueTo(ep.driver, ep.guard); err != nil {
+        return nil, err
+    }
+    return &AvisDriverDetailsPage{driver: ep.driver, guard: ep.guard}, nil
+}
+
+// DriverDetails contiene los datos del conductor principal
+type DriverDetails struct {
+    FirstName string
+    LastName  string
+    Email     string
+    Phone     string
+}
+
+// AvisDriverDetailsPage representa el paso de datos del conductor
+type AvisDriverDetailsPage struct {
+    driver playwright.Page
+    guard  *PaymentGuard
+}
+
+// Fill rellena los datos del conductor que no estén vacíos
+func (dp *AvisDriverDetailsPage) Fill(details DriverDetails) error {
+    fields := []struct {
+        label, value string
+    }{
+        {firstNameLabel, details.FirstName},
+        {lastNameLabel, details.LastName},
+        {emailLabel, details.Email},
+        {phoneLabel, details.Phone},
+    }
+    for _, field := range fields {
+        if field.value == "" {
+            continue
+        }
+        if err := dp.driver.GetByLabel(field.label, playwright.PageGetByLabelOptions{Exact: playwright.Bool(true)}).Fill(field.value); err != nil {
+            return &PageError{fmt.Sprintf("Error filling %q", field.label), err}
+        }
+    }
+    return nil
+}
+
+// Total devuelve el importe total que muestra el resumen lateral
+func (dp *AvisDriverDetailsPage) Total() (Price, error) {
+    return readTotal(dp.driver)
+}
+
+// Continue avanza al resumen de la reserva
+func (dp *AvisDriverDetailsPage) Continue() (*AvisReviewPage, error) {
+    if err := continueTo(dp.driver, dp.guard); err != nil {
+        return nil, err
+    }
+    return &AvisReviewPage{driver: dp.driver, guard: dp.guard}, nil
+}
+
+// AvisReviewPage representa el resumen previo al pago. No ofrece ningún método para
+// confirmar: el embudo termina aquí.
+type AvisReviewPage struct {
+    driver playwright.Page
+    guard  *PaymentGuard
+}
+
+// LineItems devuelve las líneas de precio del resumen
+func (rp *AvisReviewPage) LineItems() ([]LineItem, error) {
+    return readLineItems(rp.driver, summaryItemSelector)
+}
+
+// Total devuelve el importe total del resumen
+func (rp *AvisReviewPage) Total() (Price, error) {
+    return readTotal(rp.driver)
+}
+
+// Click pulsa un control del resumen a través del guardia, que rechaza los de pago o confirmación
+func (rp *AvisReviewPage) Click(name string) error {
+    return rp.guard.click(rp.driver.GetByRole("button", playwright.PageGetByRoleOptions{Name: name}).First())
+}
@@ -2,36 +2,62 @@
 package pages
 
 import (
+    "fmt"
     "log"
+    "slices"
+    "strconv"
+    "strings"
     "time"
+    "unicode"
 
+    "GoLang_FRT_E2E_Tests/pkg/consent"
+    "GoLang_FRT_E2E_Tests/pkg/emulation"
+    "GoLang_FRT_E2E_Tests/pkg/storagestate"
+    "GoLang_FRT_E2E_Tests/pkg/visual"
     "github.com/playwright-community/playwright-go"
 )
 
 // AvisPage representa la página de búsqueda de vehículos de Avis
 type AvisPage struct {
-    driver playwright.Page
+    driver  playwright.Page
+    session emulation.Session
+    consent *consent.Manager
+    // guard se instala una sola vez por página de Playwright y lo comparten todos los resultados
+    guard   *PaymentGuard
 }
 func (p *AvisPage) Title() (string, error) {
     return p.driver.Title()
 }
 
-func (p *AvisPage) AvailableVehicles() ([]string, error) {
-	VehicleList := p.driver.Locator(".vehicle")
-
-	vehicles, err := VehicleList.AllTextContents()
-	if err != nil {
-		return nil, err
-	}
-
-	return vehicles, nil
-	
+// AvailableVehicles interpreta las tarjetas de vehículos de la página de resultados
+func (p *AvisPage) AvailableVehicles() ([]Vehicle, error) {
+    return p.Results().Vehicles()
 }
 
+// Results devuelve el objeto de página de los resultados mostrados en la pestaña actual
+func (p *AvisPage) Results() *AvisResultsPage {
+    return &AvisResultsPage{driver: p.driver, search: p}
+}
 
+// paymentGuard devuelve el guardia de pago de la página, instalándolo la primera vez
+func (p *AvisPage) paymentGuard() (*PaymentGuard, error) {
+    if p.guard == nil {
+        guard, err := newPaymentGuard(p.driver)
+        if err != nil {
+            return nil, err
+        }
+        p.guard = guard
+    }
+    return p.guard, nil
+}
 
 // NewAvisPage crea una nueva instancia de AvisPage utilizando Playwright
 func NewAvisPage() *AvisPage {
+    return NewAvisPageWithSession(emulation.Session{})
+}
+
+// NewAvisPageWithSession crea una instancia de AvisPage aplicando las opciones de emulación de la sesión
+func NewAvisPageWithSession(session emulation.Session) *AvisPage {
     pw, err := playwright.Run()
     if err != nil {
         log.Fatalf("❌ Error al iniciar Playwright: %v", err)
@@ -44,12 +70,31 @@ func NewAvisPage() *AvisPage {
         log.Fatalf("❌ Error al abrir el navegador: %v", err)
     }
 
-    page, err := browser.NewPage()
+    browserContext, err := browser.NewContext(session.PlaywrightOptions())
+    if err != nil {
+        log.Fatalf("❌ Error al crear el contexto del navegador: %v", err)
+    }
+
+    page, err := browserContext.NewPage()
     if err != nil {
         log.Fatalf("❌ Error al crear la página: %v", err)
     }
 
-    return &AvisPage{driver: page}
+    if err := session.ApplyPlaywright(browserContext, page); err != nil {
+        log.Fatalf("❌ Error al aplicar las condiciones de red y CPU: %v", err)
+    }
+
+    consentManager, err := consent.ManagerFromEnv()
+    if err != nil {
+        log.Fatalf("❌ Error al cargar los proveedores de consentimiento: %v", err)
+    }
+
+    return &AvisPage{driver: page, session: session, consent: consentManager}
+}
+
+// Session devuelve las opciones de emulación con las que se creó la página
+func (ap *AvisPage) Session() emulation.Session {
+    return ap.session
 }
 
 // Close cierra el navegador
@@ -63,98 +108,491 @@ func (ap *AvisPage) NavigateTo(url string) error {
     return err
 }
 
-// AcceptCookies acepta el popup emergente de cookies
+// CaptureScreenshot captura la página completa (o el elemento indicado) enmascarando las zonas dinámicas
+func (ap *AvisPage) CaptureScreenshot(selector string, masks ...string) ([]byte, error) {
+    return visual.CapturePlaywright(ap.driver, selector, masks)
+}
+
+// AcceptCookies acepta el popup emergente de cookies. Si no aparece ningún banner no es un error.
 func (ap *AvisPage) AcceptCookies() error {
-    consentPromptLocator := ap.driver.Locator("#consent_prompt_accept")
-    return consentPromptLocator.Click()
+    _, err := ap.Consent(consent.Accept())
+    return err
 }
 
-// SearchVehicles realiza la búsqueda de vehículos disponibles
-func (ap *AvisPage) SearchVehicles(pickupTime, returnTime time.Time, pickupLocation, returnLocation string) error {
-    if err := ap.selectPickupLocation(pickupLocation); err != nil {
-        return err
+// Consent responde al banner de consentimiento que se muestre con la decisión indicada
+func (ap *AvisPage) Consent(decision consent.Decision) (consent.Result, error) {
+    result, err := ap.consent.Playwright(ap.driver, decision)
+    if err != nil {
+        return result, &PageError{"Error handling cookie consent", err}
+    }
+    return result, nil
+}
+
+// SaveStorageState guarda las cookies, el localStorage y el sessionStorage de la sesión
+// en un fichero JSON, que puede restaurarse en sesiones nuevas mediante emulation.Session.Storage
+func (ap *AvisPage) SaveStorageState(file string) (*storagestate.State, error) {
+    state, err := storagestate.CapturePlaywright(ap.driver.Context())
+    if err != nil {
+        return nil, &PageError{"Error capturing storage state", err}
+    }
+    if err := state.Save(file); err != nil {
+        return nil, &PageError{"Error saving storage state", err}
+    }
+    return state, nil
+}
+
+// SearchVehicles realiza la búsqueda de vehículos disponibles tocando solo los campos
+// que indica el criterio. Se usan la fecha y la hora de reloj de PickupTime y ReturnTime
+// tal y como vienen, en su propia zona horaria.
+func (ap *AvisPage) SearchVehicles(criteria SearchCriteria) (*AvisResultsPage, error) {
+    if err := criteria.Validate(); err != nil {
+        return nil, err
+    }
+
+    if err := ap.selectPickupLocation(criteria.PickupLocation); err != nil {
+        return nil, err
     }
 
-    if err := ap.selectReturnLocation(returnLocation); err != nil {
+    if err := ap.setDifferentReturnLocation(criteria.OneWay()); err != nil {
+        return nil, err
+    }
+    if criteria.OneWay() {
+        if err := ap.selectReturnLocation(criteria.ReturnLocation); err != nil {
+            return nil, err
+        }
+    }
+
+    if err := ap.selectPickupDateTime(criteria.PickupTime); err != nil {
+        return nil, err
+    }
+
+    if err := ap.selectReturnDateTime(criteria.ReturnTime); err != nil {
+        return nil, err
+    }
+
+    if err := ap.fillOptionalFields(criteria); err != nil {
+        return nil, err
+    }
+
+    if err := ap.simulateVehicleSearch(); err != nil {
+        return nil, err
+    }
+    return ap.Results(), nil
+}
+
+// Selectores del autocompletado de ubicaciones
+const (
+    pickupSuggestionsSelector = "#getAQuote > div:nth-child(19) > div.standard-form__col.standard-form__col--init-full > div > ul > li > button"
+    returnSuggestionsSelector = "#getAQuote > div:nth-child(19) > div.standard-form__col.standard-form__col--init-hidden > div > ul > li > button"
+    suggestionsTimeout        = 10000
+    suggestionsPollInterval   = 300 * time.Millisecond
+    returnToggleLabelSelector = "#return-location-toggle > ul > li > label"
+    returnToggleInputSelector = "#return-location-toggle input[type='checkbox']"
+)
+
+func (ap *AvisPage) selectPickupLocation(pickupLocation string) error {
+    return ap.selectLocation("#hire-search", pickupSuggestionsSelector, pickupLocation)
+}
+
+// setDifferentReturnLocation marca o desmarca la casilla de devolución en otra oficina según haga falta
+func (ap *AvisPage) setDifferentReturnLocation(different bool) error {
+    checked, err := ap.driver.Locator(returnToggleInputSelector).IsChecked()
+    if err != nil {
         return err
     }
+    if checked == different {
+        return nil
+    }
+
+	chkDifferentReturnLocation := ap.driver.Locator(returnToggleLabelSelector)
+	return chkDifferentReturnLocation.Click()
+}
 
-    if err := ap.selectPickupDateTime(pickupTime); err != nil {
+func (ap *AvisPage) selectReturnLocation(returnLocation string) error {
+    return ap.selectLocation("#return-search", returnSuggestionsSelector, returnLocation)
+}
+
+// ListLocationSuggestions escribe la consulta en el campo de recogida y devuelve las sugerencias del autocompletado
+func (ap *AvisPage) ListLocationSuggestions(query string) ([]string, error) {
+    return ap.typeLocation("#hire-search", pickupSuggestionsSelector, query)
+}
+
+// selectLocation escribe la ubicación y pulsa la sugerencia que mejor coincide con ella
+func (ap *AvisPage) selectLocation(inputSelector, suggestionsSelector, location string) error {
+    suggestions, err := ap.typeLocation(inputSelector, suggestionsSelector, location)
+    if err != nil {
         return err
     }
 
-    if err := ap.selectReturnDateTime(returnTime); err != nil {
+    index, err := MatchSuggestion(suggestions, location)
+    if err != nil {
         return err
     }
+    return ap.driver.Locator(suggestionsSelector).Nth(index).Click()
+}
 
-    return ap.simulateVehicleSearch()
+// typeLocation escribe la consulta y espera a que la lista de sugerencias deje de cambiar
+func (ap *AvisPage) typeLocation(inputSelector, suggestionsSelector, query string) ([]string, error) {
+    input := ap.driver.Locator(inputSelector)
+    if err := input.Click(); err != nil {
+        return nil, err
+    }
+    if err := input.Fill(""); err != nil {
+        return nil, err
+    }
+    if err := input.PressSequentially(query); err != nil {
+        return nil, err
+    }
+
+    suggestions := ap.driver.Locator(suggestionsSelector)
+    if err := suggestions.First().WaitFor(playwright.LocatorWaitForOptions{
+        State:   playwright.WaitForSelectorStateVisible,
+        Timeout: playwright.Float(suggestionsTimeout),
+    }); err != nil {
+        return nil, &PageError{fmt.Sprintf("No location suggestions shown for %q", query), err}
+    }
+
+    // Las sugerencias se refrescan a medida que llegan las respuestas; se espera a que se estabilicen
+    var previous []string
+    deadline := time.Now().Add(suggestionsTimeout * time.Millisecond)
+    for {
+        texts, err := suggestions.AllTextContents()
+        if err != nil {
+            return nil, err
+        }
+        for i := range texts {
+            texts[i] = strings.Join(strings.Fields(texts[i]), " ")
+        }
+        if slices.Equal(texts, previous) || time.Now().After(deadline) {
+            return texts, nil
+        }
+        previous = texts
+        time.Sleep(suggestionsPollInterval)
+    }
 }
 
-func (ap *AvisPage) selectPickupLocation(pickupLocation string) error {
-	pickupLocationLocator := ap.driver.Locator("#hire-search")
-	if err := pickupLocationLocator.Click(); err != nil { return err }
-	if err := pickupLocationLocator.PressSequentially(pickupLocation); err != nil {
-		return err
-	}
+// MatchSuggestion devuelve el índice de la sugerencia que coincide con la ubicación pedida.
+// Se prueba, por este orden y sin tener en cuenta mayúsculas ni acentos, la coincidencia
+// exacta, por prefijo, por contenido y, por último, la más parecida por palabras.
+func MatchSuggestion(suggestions []string, wanted string) (int, error) {
+    target := foldText(wanted)
+    if target == "" {
+        return -1, &PageError{"Empty location", nil}
+    }
+
+    folded := make([]string, len(suggestions))
+    for i, suggestion := range suggestions {
+        folded[i] = foldText(suggestion)
+    }
+
+    matchers := []func(string) bool{
+        func(s string) bool { return s == target },
+        func(s string) bool { return strings.HasPrefix(s, target) },
+        func(s string) bool { return strings.Contains(s, target) },
+    }
+    for _, matches := range matchers {
+        for i, s := range folded {
+            if matches(s) {
+                return i, nil
+            }
+        }
+    }
+
+    best, bestScore := -1, 0.0
+    for i, s := range folded {
+        if score := wordOverlap(target, s); score > bestScore {
+            best, bestScore = i, score
+        }
+    }
+    if bestScore >= 0.6 {
+        return best, nil
+    }
 
-    time.Sleep(2 * time.Second)
-	pickupSuggested := ap.driver.Locator("#getAQuote > div:nth-child(19) > div.standard-form__col.standard-form__col--init-full > div > ul > li:nth-child(1) > button")
-	return pickupSuggested.Click()
+    return -1, &PageError{fmt.Sprintf("No suggestion matches %q; suggestions: %q", wanted, suggestions), nil}
 }
 
-func (ap *AvisPage) selectReturnLocation(returnLocation string) error {
-	chkDifferentReturnLocation := ap.driver.Locator("#return-location-toggle > ul > li > label")
-	if err := chkDifferentReturnLocation.Click(); err != nil {return err}
-    
-	returnLocationLocator := ap.driver.Locator("#return-search")
-	if err := returnLocationLocator.Click(); err != nil { return err }
-	if err := returnLocationLocator.PressSequentially(returnLocation); err != nil {
-		return err
-	}
+// accentFolder elimina los acentos más habituales en nombres de ubicaciones
+var accentFolder = strings.NewReplacer(
+    "á", "a", "à", "a", "ä", "a", "â", "a", "ã", "a",
+    "é", "e", "è", "e", "ë", "e", "ê", "e",
+    "í", "i", "ì", "i", "ï", "i", "î", "i",
+    "ó", "o", "ò", "o", "ö", "o", "ô", "o", "õ", "o",
+    "ú", "u", "ù", "u", "ü", "u", "û", "u",
+    "ñ", "n", "ç", "c",
+)
 
-    time.Sleep(2 * time.Second)
-	returnSuggested := ap.driver.Locator("#getAQuote > div:nth-child(19) > div.standard-form__col.standard-form__col--init-hidden > div > ul > li:nth-child(1) > button")
-    return returnSuggested.Click()
+// foldText normaliza el texto a minúsculas, sin acentos, sin puntuación y con espacios simples
+func foldText(s string) string {
+    s = accentFolder.Replace(strings.ToLower(s))
+    s = strings.Map(func(r rune) rune {
+        if unicode.IsLetter(r) || unicode.IsDigit(r) {
+            return r
+        }
+        return ' '
+    }, s)
+    return strings.Join(strings.Fields(s), " ")
 }
 
+// wordOverlap devuelve la proporción de palabras de target que aparecen en candidate
+func wordOverlap(target, candidate string) float64 {
+    words := strings.Fields(target)
+    if len(words) == 0 {
+        return 0
+    }
+    present := make(map[string]bool)
+    for _, word := range strings.Fields(candidate) {
+        present[word] = true
+    }
+    found := 0
+    for _, word := range words {
+        if present[word] {
+            found++
+        }
+    }
+    return float64(found) / float64(len(words))
+}
+
+// Selectores del selector de fechas (Pikaday) y del selector de horas (jquery-timepicker)
+const (
+    dateFieldsSelector      = "#getAQuote > div.standard-form__row.booking-widget__date-fields > div:nth-child(%d) > div"
+    openDatePickerSelector  = "div.booking-widget__date-picker-container.booking-widget__date-picker-container--open"
+    dayButtonSelector       = "td:not(.is-disabled) > button[data-pika-year='%d'][data-pika-month='%d'][data-pika-day='%d']"
+    anyDayButtonSelector    = "td > button[data-pika-day]"
+    nextMonthSelector       = "button.pika-next"
+    prevMonthSelector       = "button.pika-prev"
+    timeSlotSelector        = "div.booking-widget__time-picker-container ul > li"
+    disabledTimeSlotClass   = "ui-timepicker-disabled"
+    maxMonthNavigation      = 24
+)
 
 func (ap *AvisPage) selectPickupDateTime(pickupTime time.Time) error {
-	pickupDateLocator := ap.driver.Locator("#date-from-display")
-	if err := pickupDateLocator.Click(); err != nil {
-		return err
-	}
-	pickupDateSuggested := ap.driver.Locator("#getAQuote > div.standard-form__row.booking-widget__date-fields > div:nth-child(1) > div > div.booking-widget__date-picker-container.booking-widget__date-picker-container--open > div > div > div:nth-child(1) > table > tbody > tr:nth-child(5) > td.is-selected > button")
-	if err := pickupDateSuggested.Click(); err != nil {
-		return err
-	}
-	pickupTimeLocator := ap.driver.Locator("#time-from-display")
-	if err := pickupTimeLocator.Click(); err != nil {
-		return err
-	}
-	pickupTimeSuggested := ap.driver.Locator("#getAQuote > div.standard-form__row.booking-widget__date-fields > div:nth-child(1) > div > div.booking-widget__time-picker-container > div > div > ul > li.ui-timepicker-am.ui-timepicker-selected")
-	return pickupTimeSuggested.Click()
+    return ap.selectDateTime(1, "#date-from-display", "#time-from-display", pickupTime)
 }
 
 func (ap *AvisPage) selectReturnDateTime(returnTime time.Time) error {
-	returnDateLocator := ap.driver.Locator("#date-to-display")
-	if err := returnDateLocator.Click(); err != nil {
-		return err
-	}
-	returnDateSuggested := ap.driver.Locator("#getAQuote > div.standard-form__row.booking-widget__date-fields > div:nth-child(2) > div > div.booking-widget__date-picker-container.booking-widget__date-picker-container--open > div > div > div:nth-child(1) > table > tbody > tr:nth-child(5) > td.is-selected > button")
-	if err := returnDateSuggested.Click(); err != nil {
-		return err
-	}
-	returnTimeLocator := ap.driver.Locator("#time-to-display")
-	if err := returnTimeLocator.Click(); err != nil {
-		return err
-	}
-	returnTimeSuggested := ap.driver.Locator("#getAQuote > div.standard-form__row.booking-widget__date-fields > div:nth-child(2) > div > div.booking-widget__time-picker-container > div > div > ul > li.ui-timepicker-am.ui-timepicker-selected")
-	return returnTimeSuggested.Click()
+    return ap.selectDateTime(2, "#date-to-display", "#time-to-display", returnTime)
+}
+
+// selectDateTime abre el selector de fechas del campo indicado (1 recogida, 2 devolución),
+// navega hasta el mes del día pedido, lo selecciona y elige la franja horaria más cercana
+func (ap *AvisPage) selectDateTime(field int, dateDisplay, timeDisplay string, when time.Time) error {
+    fieldLocator := ap.driver.Locator(fmt.Sprintf(dateFieldsSelector, field))
+
+    if err := ap.driver.Locator(dateDisplay).Click(); err != nil {
+        return err
+    }
+    picker := fieldLocator.Locator(openDatePickerSelector)
+    if err := picker.WaitFor(playwright.LocatorWaitForOptions{State: playwright.WaitForSelectorStateVisible}); err != nil {
+        return err
+    }
+    if err := ap.selectDay(picker, when); err != nil {
+        return err
+    }
+
+    if err := ap.driver.Locator(timeDisplay).Click(); err != nil {
+        return err
+    }
+    return ap.selectClosestTimeSlot(fieldLocator.Locator(timeSlotSelector), when)
+}
+
+// selectDay avanza o retrocede meses en el selector hasta encontrar el día y lo pulsa
+func (ap *AvisPage) selectDay(picker playwright.Locator, when time.Time) error {
+    // Pikaday numera los meses desde 0
+    day := picker.Locator(fmt.Sprintf(dayButtonSelector, when.Year(), int(when.Month())-1, when.Day()))
+    target := when.Year()*12 + int(when.Month()) - 1
+
+    for i := 0; i < maxMonthNavigation; i++ {
+        count, err := day.Count()
+        if err != nil {
+            return err
+        }
+        if count > 0 {
+            return day.First().Click()
+        }
+
+        shown, err := displayedMonth(picker)
+        if err != nil {
+            return err
+        }
+        navigation := nextMonthSelector
+        if target < shown {
+            navigation = prevMonthSelector
+        } else if target == shown {
+            return &PageError{fmt.Sprintf("Day %s is not available", when.Format("2006-01-02")), nil}
+        }
+        if err := picker.Locator(navigation).First().Click(); err != nil {
+            return &PageError{fmt.Sprintf("Cannot navigate to %s", when.Format("2006-01")), err}
+        }
+    }
+
+    return &PageError{fmt.Sprintf("Month %s not reached after %d steps", when.Format("2006-01"), maxMonthNavigation), nil}
+}
+
+// displayedMonth devuelve el primer mes visible en el selector como año*12+mes
+func displayedMonth(picker playwright.Locator) (int, error) {
+    first := picker.Locator(anyDayButtonSelector).First()
+    year, err := first.GetAttribute("data-pika-year")
+    if err != nil {
+        return 0, err
+    }
+    month, err := first.GetAttribute("data-pika-month")
+    if err != nil {
+        return 0, err
+    }
+
+    y, err := strconv.Atoi(year)
+    if err != nil {
+        return 0, &PageError{"Invalid data-pika-year", err}
+    }
+    m, err := strconv.Atoi(month)
+    if err != nil {
+        return 0, &PageError{"Invalid data-pika-month", err}
+    }
+    return y*12 + m, nil
+}
+
+// selectClosestTimeSlot elige la franja horaria habilitada más cercana a la hora pedida
+func (ap *AvisPage) selectClosestTimeSlot(slots playwright.Locator, when time.Time) error {
+    if err := slots.First().WaitFor(playwright.LocatorWaitForOptions{State: playwright.WaitForSelectorStateVisible}); err != nil {
+        return err
+    }
+    texts, err := slots.AllTextContents()
+    if err != nil {
+        return err
+    }
+
+    wanted := when.Hour()*60 + when.Minute()
+    best, bestDistance := -1, 0
+    for i, text := range texts {
+        class, err := slots.Nth(i).GetAttribute("class")
+        if err != nil {
+            return err
+        }
+        if strings.Contains(class, disabledTimeSlotClass) {
+            continue
+        }
+        minutes, ok := parseTimeSlot(text)
+        if !ok {
+            continue
+        }
+        distance := minutes - wanted
+        if distance < 0 {
+            distance = -distance
+        }
+        if best == -1 || distance < bestDistance {
+            best, bestDistance = i, distance
+        }
+    }
+
+    if best == -1 {
+        return &PageError{fmt.Sprintf("No time slot available near %s in %v", when.Format("15:04"), texts), nil}
+    }
+    return slots.Nth(best).Click()
 }
 
+// parseTimeSlot convierte una franja como "10:30", "10:30 AM" o "10.30pm" en minutos desde medianoche
+func parseTimeSlot(text string) (int, bool) {
+    text = strings.ToLower(strings.Join(strings.Fields(text), ""))
+    pm := strings.HasSuffix(text, "pm")
+    am := strings.HasSuffix(text, "am")
+    text = strings.TrimSuffix(strings.TrimSuffix(text, "pm"), "am")
 
+    hour, minute, found := strings.Cut(strings.ReplaceAll(text, ".", ":"), ":")
+    if !found {
+        return 0, false
+    }
+    h, err := strconv.Atoi(hour)
+    if err != nil || h < 0 || h > 23 {
+        return 0, false
+    }
+    m, err := strconv.Atoi(minute)
+    if err != nil || m < 0 || m > 59 {
+        return 0, false
+    }
+    if pm && h < 12 {
+        h += 12
+    } else if am && h == 12 {
+        h = 0
+    }
+    return h*60 + m, true
+}
+
+// Etiquetas de los campos opcionales del formulario de búsqueda
+const (
+    driverAgeLabel        = "Edad del conductor"
+    residenceCountryLabel = "País de residencia"
+    discountCodeLabel     = "Código AWD"
+    discountCodeToggle    = "¿Tienes un código de descuento?"
+    vehicleTypeLabel      = "Tipo de vehículo"
+    resultsTitleSelector  = "#title__heading"
+)
+
+// fillOptionalFields rellena solo los campos opcionales que indica el criterio
+func (ap *AvisPage) fillOptionalFields(criteria SearchCriteria) error {
+    if criteria.DriverAge > 0 {
+        if err := ap.selectOptionByLabel(driverAgeLabel, strconv.Itoa(criteria.DriverAge)); err != nil {
+            return err
+        }
+    }
+
+    if criteria.ResidenceCountry != "" {
+        if err := ap.selectOptionByLabel(residenceCountryLabel, criteria.ResidenceCountry); err != nil {
+            return err
+        }
+    }
+
+    if criteria.DiscountCode != "" {
+        // El campo del código de descuento está plegado en algunas variantes del formulario
+        toggle := ap.driver.GetByText(discountCodeToggle)
+        if visible, _ := toggle.IsVisible(); visible {
+            if err := toggle.Click(); err != nil {
+                return err
+            }
+        }
+        if err := ap.driver.GetByLabel(discountCodeLabel).Fill(criteria.DiscountCode); err != nil {
+            return &PageError{"Error filling discount code", err}
+        }
+    }
+
+    if criteria.VehicleType != "" {
+        if err := ap.selectOptionByLabel(vehicleTypeLabel, criteria.VehicleType); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// selectOptionByLabel elige la opción cuyo texto o valor coincide en el desplegable con la etiqueta indicada
+func (ap *AvisPage) selectOptionByLabel(label, option string) error {
+    field := ap.driver.GetByLabel(label)
+    if _, err := field.SelectOption(playwright.SelectOptionValues{Labels: &[]string{option}}); err == nil {
+        return nil
+    }
+    if _, err := field.SelectOption(playwright.SelectOptionValues{Values: &[]string{option}}); err != nil {
+        return &PageError{fmt.Sprintf("Option %q not available in %q", option, label), err}
+    }
+    return nil
+}
 
 func (ap *AvisPage) simulateVehicleSearch() error {
+    if err := ap.clickSearch(); err != nil {
+        return err
+    }
+
+    // Si el sitio rechaza la búsqueda, se informa de sus mensajes en lugar del tiempo de espera
+    validationErrors, err := ap.waitForSearchOutcome()
+    if err != nil {
+        return err
+    }
+    if len(validationErrors) > 0 {
+        return validationErrors
+    }
+    return nil
+}
+
+// clickSearch pulsa el botón "Buscar" cuando está visible
+func (ap *AvisPage) clickSearch() error {
     // Asegurarse de que el botón esté visible y habilitado antes de hacer clic
     btnBuscar := ap.driver.GetByRole("button", playwright.PageGetByRoleOptions{
         Name: "Buscar",
@@ -165,14 +603,5 @@ func (ap *AvisPage) simulateVehicleSearch() error {
     }); err != nil {
         return err
     }
-    if err := btnBuscar.Click(); err != nil {
-        return err
-    }
-    titleLocator := ap.driver.Locator("#title__heading")
-    err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-        State:   playwright.WaitForSelectorStateVisible,
-        Timeout: playwright.Float(20000), // 20 segundos de tiempo de espera
-    })
-    return err
+    return btnBuscar.Click()
 }
-
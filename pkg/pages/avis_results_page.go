@@ -0,0 +1,282 @@
+// pkg/pages/avis_results_page.go
+package pages
+
+import (
+    "fmt"
+    "math"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/playwright-community/playwright-go"
+)
+
+// SortOrder representa un criterio de ordenación de los resultados de Avis
+type SortOrder string
+
+const (
+    SortRecommended     SortOrder = "Recomendado"
+    SortPriceAscending  SortOrder = "Precio: de menor a mayor"
+    SortPriceDescending SortOrder = "Precio: de mayor a menor"
+)
+
+// Selectores y etiquetas de la página de resultados
+const (
+    resultsCountSelector = ".results-count, .search-results__count"
+    vehicleCardSelector  = ".vehicle"
+    filtersPanelSelector = ".filters, .search-filters"
+    activeFilterSelector = ".active-filter, .filter-tag, .filters__applied li"
+    clearFiltersLabel    = "Borrar filtros"
+    sortLabel            = "Ordenar por"
+    minPriceLabel        = "Precio mínimo"
+    maxPriceLabel        = "Precio máximo"
+    selectVehicleLabel   = "Seleccionar"
+    resultsSettleTimeout = 15 * time.Second
+    resultsPollInterval  = 500 * time.Millisecond
+)
+
+// transmissionFilterLabels relaciona cada tipo de cambio con la etiqueta de su filtro
+var transmissionFilterLabels = map[Transmission]string{
+    TransmissionManual:    "Manual",
+    TransmissionAutomatic: "Automático",
+}
+
+// AvisResultsPage representa la página de resultados a la que lleva la búsqueda de vehículos
+type AvisResultsPage struct {
+    driver playwright.Page
+    search *AvisPage
+}
+
+// Title devuelve el título de la página de resultados
+func (rp *AvisResultsPage) Title() (string, error) {
+    return rp.driver.Title()
+}
+
+// Vehicles interpreta las tarjetas de vehículos que se muestran con los filtros actuales
+func (rp *AvisResultsPage) Vehicles() ([]Vehicle, error) {
+    cards, err := rp.driver.Locator(vehicleCardSelector).All()
+    if err != nil {
+        return nil, err
+    }
+
+    vehicles := make([]Vehicle, 0, len(cards))
+    for _, card := range cards {
+        text, err := card.InnerText()
+        if err != nil {
+            return nil, &PageError{"Error reading vehicle card", err}
+        }
+        vehicles = append(vehicles, ParseVehicle(text))
+    }
+
+    return vehicles, nil
+}
+
+// ResultCount devuelve el número de resultados que anuncia la página, o el número de tarjetas si no lo anuncia
+func (rp *AvisResultsPage) ResultCount() (int, error) {
+    counter := rp.driver.Locator(resultsCountSelector).First()
+    if visible, _ := counter.IsVisible(); visible {
+        text, err := counter.InnerText()
+        if err != nil {
+            return 0, err
+        }
+        if count, ok := ParseResultCount(text); ok {
+            return count, nil
+        }
+    }
+
+    return rp.driver.Locator(vehicleCardSelector).Count()
+}
+
+// ActiveFilters devuelve el texto de los filtros aplicados
+func (rp *AvisResultsPage) ActiveFilters() ([]string, error) {
+    texts, err := rp.driver.Locator(activeFilterSelector).AllInnerTexts()
+    if err != nil {
+        return nil, err
+    }
+
+    var filters []string
+    for _, text := range texts {
+        if text = strings.Join(strings.Fields(text), " "); text != "" {
+            filters = append(filters, text)
+        }
+    }
+    return filters, nil
+}
+
+// FilterByCategory marca el filtro de la categoría indicada (Compacto, SUV, ...)
+func (rp *AvisResultsPage) FilterByCategory(category string) error {
+    return rp.checkFilter(category)
+}
+
+// FilterByTransmission marca el filtro del tipo de cambio indicado
+func (rp *AvisResultsPage) FilterByTransmission(transmission Transmission) error {
+    label, ok := transmissionFilterLabels[transmission]
+    if !ok {
+        return &PageError{fmt.Sprintf("Unknown transmission %q", transmission), nil}
+    }
+    return rp.checkFilter(label)
+}
+
+// FilterByPriceRange limita los resultados al rango de precios indicado, en euros
+func (rp *AvisResultsPage) FilterByPriceRange(min, max float64) error {
+    if max < min {
+        return &PageError{fmt.Sprintf("Invalid price range %.2f-%.2f", min, max), nil}
+    }
+
+    // El filtro solo admite euros enteros: se amplía el rango para no perder resultados dentro de él
+    panel := rp.driver.Locator(filtersPanelSelector).First()
+    bounds := []struct {
+        label string
+        value float64
+    }{{minPriceLabel, math.Floor(min)}, {maxPriceLabel, math.Ceil(max)}}
+    for _, bound := range bounds {
+        if err := panel.GetByLabel(bound.label).Fill(strconv.Itoa(int(bound.value))); err != nil {
+            return &PageError{fmt.Sprintf("Error filling %q", bound.label), err}
+        }
+    }
+    if err := panel.GetByLabel(maxPriceLabel).Press("Enter"); err != nil {
+        return err
+    }
+
+    return rp.waitForResults()
+}
+
+// ClearFilters quita todos los filtros aplicados
+func (rp *AvisResultsPage) ClearFilters() error {
+    button := rp.driver.GetByRole("button", playwright.PageGetByRoleOptions{Name: clearFiltersLabel})
+    if visible, _ := button.IsVisible(); !visible {
+        return nil
+    }
+    if err := button.Click(); err != nil {
+        return err
+    }
+    return rp.waitForResults()
+}
+
+// SortBy cambia el orden de los resultados
+func (rp *AvisResultsPage) SortBy(order SortOrder) error {
+    if _, err := rp.driver.GetByLabel(sortLabel).SelectOption(playwright.SelectOptionValues{
+        Labels: &[]string{string(order)},
+    }); err != nil {
+        return &PageError{fmt.Sprintf("Error sorting by %q", order), err}
+    }
+    return rp.waitForResults()
+}
+
+// SelectVehicle pulsa el botón de selección de la tarjeta cuyo modelo coincide con el indicado
+func (rp *AvisResultsPage) SelectVehicle(model string) (*AvisExtrasPage, error) {
+    vehicles, err := rp.Vehicles()
+    if err != nil {
+        return nil, err
+    }
+
+    models := make([]string, len(vehicles))
+    for i, vehicle := range vehicles {
+        models[i] = vehicle.Model
+    }
+    index, err := MatchSuggestion(models, model)
+    if err != nil {
+        return nil, err
+    }
+    if !vehicles[index].Available {
+        return nil, &PageError{fmt.Sprintf("Vehicle %q is not available", vehicles[index].Model), nil}
+    }
+
+    return rp.SelectVehicleAt(index)
+}
+
+// SelectVehicleAt pulsa el botón de selección de la tarjeta en la posición indicada y
+// devuelve el paso de extras. A partir de aquí el guardia de pago queda instalado.
+func (rp *AvisResultsPage) SelectVehicleAt(index int) (*AvisExtrasPage, error) {
+    guard, err := rp.search.paymentGuard()
+    if err != nil {
+        return nil, err
+    }
+
+    card := rp.driver.Locator(vehicleCardSelector).Nth(index)
+    button := card.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: selectVehicleLabel}).First()
+    if err := guard.click(button); err != nil {
+        return nil, &PageError{fmt.Sprintf("Error selecting vehicle %d", index), err}
+    }
+    if err := rp.driver.Locator(extraItemSelector).First().WaitFor(playwright.LocatorWaitForOptions{
+        State:   playwright.WaitForSelectorStateVisible,
+        Timeout: playwright.Float(20000),
+    }); err != nil {
+        return nil, &PageError{"Extras step not shown", err}
+    }
+
+    return &AvisExtrasPage{driver: rp.driver, guard: guard}, nil
+}
+
+// checkFilter marca la casilla del panel de filtros con la etiqueta indicada, si no lo está ya
+func (rp *AvisResultsPage) checkFilter(label string) error {
+    checkbox := rp.driver.Locator(filtersPanelSelector).First().GetByRole("checkbox", playwright.LocatorGetByRoleOptions{
+        Name: label,
+    })
+    checked, err := checkbox.IsChecked()
+    if err != nil {
+        return &PageError{fmt.Sprintf("Filter %q not found", label), err}
+    }
+    if !checked {
+        if err := checkbox.Check(); err != nil {
+            return err
+        }
+    }
+    return rp.waitForResults()
+}
+
+// waitForResults espera a que el listado se vuelva a pintar y el número de tarjetas deje de cambiar
+func (rp *AvisResultsPage) waitForResults() error {
+    _ = rp.driver.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+        State: playwright.LoadStateNetworkidle,
+    })
+
+    cards := rp.driver.Locator(vehicleCardSelector)
+    previous := -1
+    deadline := time.Now().Add(resultsSettleTimeout)
+    for {
+        count, err := cards.Count()
+        if err != nil {
+            return err
+        }
+        if count == previous || time.Now().After(deadline) {
+            return nil
+        }
+        previous = count
+        time.Sleep(resultsPollInterval)
+    }
+}
+
+var resultCountPattern = regexp.MustCompile(`(\d[\d.]*)\s*(?:vehículos?|vehiculos?|resultados?|coches?)`)
+
+// ParseResultCount extrae el número de resultados de textos como "24 vehículos disponibles"
+func ParseResultCount(text string) (int, bool) {
+    match := resultCountPattern.FindStringSubmatch(strings.ToLower(text))
+    if match == nil {
+        return 0, false
+    }
+    count, err := strconv.Atoi(strings.ReplaceAll(match[1], ".", ""))
+    return count, err == nil
+}
+
+// SortedByPrice indica si los vehículos disponibles están ordenados por su precio más bajo
+func SortedByPrice(vehicles []Vehicle, ascending bool) bool {
+    var previous *Price
+    for _, vehicle := range vehicles {
+        price := vehicle.LowestPrice()
+        if !vehicle.Available || price.IsZero() {
+            continue
+        }
+        if previous != nil {
+            if ascending && price.Amount < previous.Amount {
+                return false
+            }
+            if !ascending && price.Amount > previous.Amount {
+                return false
+            }
+        }
+        previous = &price
+    }
+    return true
+}
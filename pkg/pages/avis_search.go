@@ -0,0 +1,69 @@
+// pkg/pages/avis_search.go
+package pages
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// Edad mínima y máxima del conductor admitidas por el formulario de Avis
+const (
+    MinDriverAge = 18
+    MaxDriverAge = 99
+)
+
+// SearchCriteria describe una búsqueda de vehículos en Avis. Los campos opcionales
+// con su valor cero no se tocan y el formulario conserva el valor que muestra el sitio.
+type SearchCriteria struct {
+    PickupLocation string
+    // ReturnLocation vacío indica devolución en la misma oficina de recogida
+    ReturnLocation   string
+    PickupTime       time.Time
+    ReturnTime       time.Time
+    DriverAge        int
+    ResidenceCountry string
+    DiscountCode     string
+    VehicleType      string
+}
+
+// OneWay indica si el vehículo se devuelve en una oficina distinta de la de recogida
+func (c SearchCriteria) OneWay() bool {
+    return c.ReturnLocation != "" && foldText(c.ReturnLocation) != foldText(c.PickupLocation)
+}
+
+// Validate comprueba las combinaciones que se pueden detectar antes de tocar el formulario
+// y devuelve ValidationErrors con origen ValidationClient.
+func (c SearchCriteria) Validate() error {
+    var errs ValidationErrors
+    invalid := func(field, message string) {
+        errs = append(errs, ValidationError{Field: field, Message: message, Source: ValidationClient})
+    }
+
+    if strings.TrimSpace(c.PickupLocation) == "" {
+        invalid(FieldPickupLocation, "La oficina de recogida es obligatoria")
+    }
+
+    switch {
+    case c.PickupTime.IsZero():
+        invalid(FieldPickupDate, "La fecha de recogida es obligatoria")
+    case c.PickupTime.Before(time.Now()):
+        invalid(FieldPickupDate, fmt.Sprintf("La fecha de recogida %s ya ha pasado", c.PickupTime.Format("2006-01-02 15:04")))
+    }
+
+    switch {
+    case c.ReturnTime.IsZero():
+        invalid(FieldReturnDate, "La fecha de devolución es obligatoria")
+    case !c.PickupTime.IsZero() && !c.ReturnTime.After(c.PickupTime):
+        invalid(FieldReturnDate, fmt.Sprintf("La fecha de devolución %s debe ser posterior a la de recogida %s", c.ReturnTime.Format("2006-01-02 15:04"), c.PickupTime.Format("2006-01-02 15:04")))
+    }
+
+    if c.DriverAge != 0 && (c.DriverAge < MinDriverAge || c.DriverAge > MaxDriverAge) {
+        invalid(FieldDriverAge, fmt.Sprintf("La edad del conductor %d está fuera del rango %d-%d", c.DriverAge, MinDriverAge, MaxDriverAge))
+    }
+
+    if len(errs) > 0 {
+        return errs
+    }
+    return nil
+}
@@ -0,0 +1,264 @@
+// pkg/pages/avis_validation.go
+package pages
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/playwright-community/playwright-go"
+)
+
+// Campos del formulario de búsqueda a los que se asocian los errores de validación
+const (
+    FieldPickupLocation = "pickup_location"
+    FieldReturnLocation = "return_location"
+    FieldPickupDate     = "pickup_date"
+    FieldReturnDate     = "return_date"
+    FieldDriverAge      = "driver_age"
+)
+
+// ValidationSource indica dónde se ha detectado un error de validación
+type ValidationSource string
+
+const (
+    // ValidationClient son las comprobaciones de SearchCriteria antes de tocar el formulario
+    ValidationClient ValidationSource = "client"
+    // ValidationWidget indica que un control del formulario no ha aceptado el valor pedido
+    ValidationWidget ValidationSource = "widget"
+    // ValidationInline son los mensajes que el sitio muestra junto a un campo
+    ValidationInline ValidationSource = "inline"
+    // ValidationBanner son los avisos generales del formulario
+    ValidationBanner ValidationSource = "banner"
+)
+
+// Selectores de los mensajes de validación del sitio
+const (
+    inlineErrorSelector  = ".form-error, .error-message, .standard-form__error"
+    bannerErrorSelector  = "[role='alert'], .alert--error, .notification--error"
+    searchOutcomeTimeout = 20 * time.Second
+    searchOutcomePoll    = 500 * time.Millisecond
+)
+
+// fieldIDs relaciona los identificadores de los controles del formulario con los campos
+var fieldIDs = map[string]string{
+    "hire-search":       FieldPickupLocation,
+    "return-search":     FieldReturnLocation,
+    "date-from-display": FieldPickupDate,
+    "time-from-display": FieldPickupDate,
+    "date-to-display":   FieldReturnDate,
+    "time-to-display":   FieldReturnDate,
+    "driver-age":        FieldDriverAge,
+}
+
+// fieldOfErrorScript busca el control del bloque del formulario al que pertenece un mensaje
+const fieldOfErrorScript = `el => {
+    const block = el.closest('.standard-form__col, .form-group, .standard-form__row');
+    const input = block && block.querySelector('input, select');
+    return input ? (input.id || input.name || '') : '';
+}`
+
+// ValidationError es un error de validación de la búsqueda
+type ValidationError struct {
+    Field   string
+    Message string
+    Source  ValidationSource
+}
+
+func (e ValidationError) Error() string {
+    if e.Field == "" {
+        return fmt.Sprintf("%s: %s", e.Source, e.Message)
+    }
+    return fmt.Sprintf("%s %s: %s", e.Source, e.Field, e.Message)
+}
+
+// ValidationErrors agrupa los errores de validación de una búsqueda
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+    messages := make([]string, len(e))
+    for i, validationError := range e {
+        messages[i] = validationError.Error()
+    }
+    return "búsqueda rechazada: " + strings.Join(messages, "; ")
+}
+
+// ForField devuelve los errores asociados al campo indicado
+func (e ValidationErrors) ForField(field string) ValidationErrors {
+    var result ValidationErrors
+    for _, validationError := range e {
+        if validationError.Field == field {
+            result = append(result, validationError)
+        }
+    }
+    return result
+}
+
+// AsValidationErrors extrae los errores de validación de err, si los contiene
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+    var validationErrors ValidationErrors
+    if errors.As(err, &validationErrors) {
+        return validationErrors, true
+    }
+    return nil, false
+}
+
+// ValidationErrors lee los mensajes de validación visibles junto a los campos y en los avisos generales
+func (ap *AvisPage) ValidationErrors() (ValidationErrors, error) {
+    var result ValidationErrors
+
+    inline, err := visibleTexts(ap.driver.Locator(inlineErrorSelector), func(element playwright.Locator) string {
+        value, err := element.Evaluate(fieldOfErrorScript, nil)
+        id, _ := value.(string)
+        if err != nil || id == "" {
+            return ""
+        }
+        if field, ok := fieldIDs[id]; ok {
+            return field
+        }
+        return id
+    })
+    if err != nil {
+        return nil, err
+    }
+    for _, message := range inline {
+        result = append(result, ValidationError{Field: message[0], Message: message[1], Source: ValidationInline})
+    }
+
+    banners, err := visibleTexts(ap.driver.Locator(bannerErrorSelector), nil)
+    if err != nil {
+        return nil, err
+    }
+    for _, message := range banners {
+        result = append(result, ValidationError{Message: message[1], Source: ValidationBanner})
+    }
+
+    return result, nil
+}
+
+// visibleTexts devuelve, para cada elemento visible con texto, el campo que indique fieldOf y su texto
+func visibleTexts(locator playwright.Locator, fieldOf func(playwright.Locator) string) ([][2]string, error) {
+    elements, err := locator.All()
+    if err != nil {
+        return nil, err
+    }
+
+    var texts [][2]string
+    for _, element := range elements {
+        if visible, _ := element.IsVisible(); !visible {
+            continue
+        }
+        text, err := element.InnerText()
+        if err != nil {
+            return nil, err
+        }
+        if text = strings.Join(strings.Fields(text), " "); text == "" {
+            continue
+        }
+        field := ""
+        if fieldOf != nil {
+            field = fieldOf(element)
+        }
+        texts = append(texts, [2]string{field, text})
+    }
+    return texts, nil
+}
+
+// waitForSearchOutcome espera a que aparezca la página de resultados o algún mensaje de validación
+func (ap *AvisPage) waitForSearchOutcome() (ValidationErrors, error) {
+    title := ap.driver.Locator(resultsTitleSelector)
+    deadline := time.Now().Add(searchOutcomeTimeout)
+    for {
+        if visible, _ := title.IsVisible(); visible {
+            return nil, nil
+        }
+        validationErrors, err := ap.ValidationErrors()
+        if err != nil {
+            return nil, err
+        }
+        if len(validationErrors) > 0 {
+            return validationErrors, nil
+        }
+        if time.Now().After(deadline) {
+            return nil, &PageError{"Neither results nor validation messages shown after searching", nil}
+        }
+        time.Sleep(searchOutcomePoll)
+    }
+}
+
+// SubmitSearch rellena el formulario tal y como indica el criterio, aunque sea inválido,
+// pulsa "Buscar" y devuelve los errores de validación del widget y del sitio. Si la
+// búsqueda llega a la página de resultados devuelve una lista vacía. Los campos vacíos
+// del criterio se dejan sin rellenar para comprobar los mensajes de campo obligatorio.
+func (ap *AvisPage) SubmitSearch(criteria SearchCriteria) (ValidationErrors, error) {
+    var rejected ValidationErrors
+    widgetError := func(field string, err error) error {
+        var pageError *PageError
+        if !errors.As(err, &pageError) {
+            return err
+        }
+        rejected = append(rejected, ValidationError{Field: field, Message: pageError.Message, Source: ValidationWidget})
+        return nil
+    }
+
+    if criteria.PickupLocation != "" {
+        if err := ap.selectPickupLocation(criteria.PickupLocation); err != nil {
+            if err := widgetError(FieldPickupLocation, err); err != nil {
+                return nil, err
+            }
+        }
+    }
+
+    if err := ap.setDifferentReturnLocation(criteria.OneWay()); err != nil {
+        return nil, err
+    }
+    if criteria.OneWay() {
+        if err := ap.selectReturnLocation(criteria.ReturnLocation); err != nil {
+            if err := widgetError(FieldReturnLocation, err); err != nil {
+                return nil, err
+            }
+        }
+    }
+
+    if !criteria.PickupTime.IsZero() {
+        if err := ap.selectPickupDateTime(criteria.PickupTime); err != nil {
+            if err := widgetError(FieldPickupDate, err); err != nil {
+                return nil, err
+            }
+        }
+    }
+    if !criteria.ReturnTime.IsZero() {
+        if err := ap.selectReturnDateTime(criteria.ReturnTime); err != nil {
+            if err := widgetError(FieldReturnDate, err); err != nil {
+                return nil, err
+            }
+        }
+    }
+
+    if criteria.DriverAge > 0 {
+        if err := ap.selectOptionByLabel(driverAgeLabel, strconv.Itoa(criteria.DriverAge)); err != nil {
+            if err := widgetError(FieldDriverAge, err); err != nil {
+                return nil, err
+            }
+        }
+    }
+    optional := criteria
+    optional.DriverAge = 0
+    if err := ap.fillOptionalFields(optional); err != nil {
+        if err := widgetError("", err); err != nil {
+            return nil, err
+        }
+    }
+
+    if err := ap.clickSearch(); err != nil {
+        return nil, err
+    }
+    siteErrors, err := ap.waitForSearchOutcome()
+    if err != nil {
+        return nil, err
+    }
+
+    return append(rejected, siteErrors...), nil
+}
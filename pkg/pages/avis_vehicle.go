@@ -0,0 +1,211 @@
+// pkg/pages/avis_vehicle.go
+package pages
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Transmission representa el tipo de cambio de un vehículo
+type Transmission string
+
+const (
+    TransmissionUnknown   Transmission = ""
+    TransmissionManual    Transmission = "manual"
+    TransmissionAutomatic Transmission = "automatic"
+)
+
+// Price representa un importe con su moneda
+type Price struct {
+    Amount   float64
+    Currency string
+}
+
+// IsZero indica si el precio no se ha encontrado
+func (p Price) IsZero() bool {
+    return p.Amount == 0 && p.Currency == ""
+}
+
+func (p Price) String() string {
+    if p.IsZero() {
+        return "-"
+    }
+    return fmt.Sprintf("%.2f %s", p.Amount, p.Currency)
+}
+
+// Vehicle representa una tarjeta de vehículo de la página de resultados de Avis
+type Vehicle struct {
+    Model            string
+    OrSimilar        bool
+    Group            string
+    Category         string
+    ACRISS           string
+    Transmission     Transmission
+    Seats            int
+    Doors            int
+    Luggage          int
+    UnlimitedMileage bool
+    MileageLimit     int
+    PayNow           Price
+    PayLater         Price
+    Available        bool
+    Raw              string
+}
+
+// LowestPrice devuelve el menor de los precios disponibles del vehículo
+func (v Vehicle) LowestPrice() Price {
+    switch {
+    case v.PayNow.IsZero():
+        return v.PayLater
+    case v.PayLater.IsZero(), v.PayNow.Amount <= v.PayLater.Amount:
+        return v.PayNow
+    default:
+        return v.PayLater
+    }
+}
+
+// Categorías comerciales de Avis, en el orden en que se buscan en la tarjeta
+var vehicleCategories = []string{
+    "Mini", "Económico", "Compacto", "Intermedio", "Estándar", "Familiar", "Superior",
+    "Premium", "Lujo", "SUV", "Monovolumen", "Furgoneta", "Descapotable", "Eléctrico",
+}
+
+var (
+    orSimilarPattern   = regexp.MustCompile(`(?i)^(.*?)\s+o\s+similar`)
+    groupPattern       = regexp.MustCompile(`(?i)\bgrupo\s+([A-Z0-9]{1,3})\b`)
+    acrissPattern      = regexp.MustCompile(`\b[MNEHCDIJSRFGPULWOX][BCDWVLSTFJXPQZEMRHYNGK][MNCABD][RNDQHIECLSABMFVZUX]\b`)
+    seatsPattern       = regexp.MustCompile(`(?i)(\d+)\s*(?:plazas|asientos|pasajeros)`)
+    doorsPattern       = regexp.MustCompile(`(?i)(\d+)\s*puertas`)
+    luggagePattern     = regexp.MustCompile(`(?i)(\d+)\s*(?:maletas|maleta|equipajes|bultos)`)
+    mileagePattern     = regexp.MustCompile(`(?i)(\d[\d.]*)\s*km`)
+    pricePattern       = regexp.MustCompile(`(€|EUR|US\$|\$|£|GBP)?\s*(\d{1,3}(?:\.\d{3})+(?:,\d{1,2})?|\d+(?:,\d{1,2})?)\s*(€|EUR|US\$|\$|£|GBP)?`)
+    payNowPattern      = regexp.MustCompile(`(?i)(pag(?:ar|ue|a)\s+ahora|prepago)`)
+    payLaterPattern    = regexp.MustCompile(`(?i)(pag(?:ar|ue|a)\s+(?:después|despues|más tarde|mas tarde|en\s+(?:el\s+)?mostrador|en\s+la\s+recogida))`)
+    unavailablePattern = regexp.MustCompile(`(?i)(no\s+disponible|agotado|sin\s+disponibilidad)`)
+)
+
+// currencyCodes normaliza los símbolos de moneda a su código ISO
+var currencyCodes = map[string]string{
+    "€": "EUR", "EUR": "EUR", "$": "USD", "US$": "USD", "£": "GBP", "GBP": "GBP",
+}
+
+// ParsePrice interpreta un importe en formato español, por ejemplo "1.234,56 €"
+func ParsePrice(text string) (Price, error) {
+    for _, match := range pricePattern.FindAllStringSubmatch(text, -1) {
+        symbol := match[1]
+        if symbol == "" {
+            symbol = match[3]
+        }
+        if symbol == "" {
+            continue
+        }
+
+        number := strings.ReplaceAll(match[2], ".", "")
+        number = strings.Replace(number, ",", ".", 1)
+        amount, err := strconv.ParseFloat(number, 64)
+        if err != nil {
+            return Price{}, &PageError{fmt.Sprintf("Invalid price %q", text), err}
+        }
+        return Price{Amount: amount, Currency: currencyCodes[symbol]}, nil
+    }
+
+    return Price{}, &PageError{fmt.Sprintf("No price found in %q", text), nil}
+}
+
+// ParseVehicle interpreta el texto de una tarjeta de vehículo, con una línea por dato
+func ParseVehicle(text string) Vehicle {
+    vehicle := Vehicle{Raw: text, Available: !unavailablePattern.MatchString(text)}
+
+    var lines []string
+    for _, line := range strings.Split(text, "\n") {
+        if line = strings.Join(strings.Fields(line), " "); line != "" {
+            lines = append(lines, line)
+        }
+    }
+
+    for _, line := range lines {
+        if match := orSimilarPattern.FindStringSubmatch(line); match != nil {
+            vehicle.Model = strings.TrimSpace(match[1])
+            vehicle.OrSimilar = true
+            break
+        }
+    }
+    if vehicle.Model == "" && len(lines) > 0 {
+        vehicle.Model = lines[0]
+    }
+
+    folded := foldText(text)
+    for _, category := range vehicleCategories {
+        if strings.Contains(" "+folded+" ", " "+foldText(category)+" ") {
+            vehicle.Category = category
+            break
+        }
+    }
+
+    if match := groupPattern.FindStringSubmatch(text); match != nil {
+        vehicle.Group = strings.ToUpper(match[1])
+    }
+    vehicle.ACRISS = acrissPattern.FindString(text)
+
+    switch {
+    case strings.Contains(folded, "automatic"):
+        vehicle.Transmission = TransmissionAutomatic
+    case strings.Contains(folded, "manual"):
+        vehicle.Transmission = TransmissionManual
+    }
+
+    vehicle.Seats = firstNumber(seatsPattern, text)
+    vehicle.Doors = firstNumber(doorsPattern, text)
+    vehicle.Luggage = firstNumber(luggagePattern, text)
+
+    if strings.Contains(folded, "kilometraje ilimitado") || strings.Contains(folded, "km ilimitados") {
+        vehicle.UnlimitedMileage = true
+    } else if match := mileagePattern.FindStringSubmatch(text); match != nil {
+        vehicle.MileageLimit, _ = strconv.Atoi(strings.ReplaceAll(match[1], ".", ""))
+    }
+
+    parseVehiclePrices(&vehicle, lines)
+    return vehicle
+}
+
+// parseVehiclePrices asigna los importes a pagar ahora o después según la etiqueta de su línea o de la anterior
+func parseVehiclePrices(vehicle *Vehicle, lines []string) {
+    var label *Price
+    for _, line := range lines {
+        switch {
+        case payNowPattern.MatchString(line):
+            label = &vehicle.PayNow
+        case payLaterPattern.MatchString(line):
+            label = &vehicle.PayLater
+        }
+
+        price, err := ParsePrice(line)
+        if err != nil {
+            continue
+        }
+
+        target := label
+        if target == nil {
+            target = &vehicle.PayNow
+            if !vehicle.PayNow.IsZero() {
+                target = &vehicle.PayLater
+            }
+        }
+        if target.IsZero() {
+            *target = price
+        }
+        label = nil
+    }
+}
+
+// firstNumber devuelve el primer número capturado por el patrón, o 0 si no aparece
+func firstNumber(pattern *regexp.Regexp, text string) int {
+    match := pattern.FindStringSubmatch(text)
+    if match == nil {
+        return 0
+    }
+    n, _ := strconv.Atoi(match[1])
+    return n
+}
@@ -2,11 +2,18 @@
 package pages
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"GoLang_FRT_E2E_Tests/pkg/links"
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"GoLang_FRT_E2E_Tests/pkg/security"
+	"GoLang_FRT_E2E_Tests/pkg/seo"
+	"GoLang_FRT_E2E_Tests/pkg/snapshot"
 	"github.com/PuerkitoBio/goquery"
 )
 
@@ -36,8 +43,25 @@ func NewHomePage() *HomePage {
 	}
 }
 
-// fetchContent obtiene el contenido de la página
-func (h *HomePage) fetchContent() (*goquery.Document, error) {
+// FetchResult contiene el documento descargado junto con los datos de la respuesta
+type FetchResult struct {
+	Doc        *goquery.Document
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Cookies    []*http.Cookie
+	TLS        *tls.ConnectionState
+}
+
+// SetClient sustituye el cliente HTTP utilizado para descargar la página
+func (h *HomePage) SetClient(client *http.Client) {
+	h.client = client
+}
+
+// Fetch descarga la página y conserva las cabeceras y el estado TLS de la respuesta. Un
+// código de estado distinto de 200 no es un error: se devuelve en StatusCode para que
+// quien llama decida, por ejemplo para auditar también las páginas de error.
+func (h *HomePage) Fetch() (*FetchResult, error) {
 	req, err := http.NewRequest("GET", h.URL, nil)
 	if err != nil {
 		return nil, &PageError{"Error creating request", err}
@@ -51,19 +75,36 @@ func (h *HomePage) fetchContent() (*goquery.Document, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, &PageError{
-			Message: fmt.Sprintf("Status code error: %d", resp.StatusCode),
-			Err:     nil,
-		}
-	}
-
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, &PageError{"Error parsing HTML", err}
 	}
 
-	return doc, nil
+	return &FetchResult{
+		Doc:        doc,
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Cookies:    resp.Cookies(),
+		TLS:        resp.TLS,
+	}, nil
+}
+
+// fetchContent obtiene el contenido de la página
+func (h *HomePage) fetchContent() (*goquery.Document, error) {
+	result, err := h.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if result.StatusCode != 200 {
+		return nil, &PageError{
+			Message: fmt.Sprintf("Status code error: %d", result.StatusCode),
+			Err:     nil,
+		}
+	}
+
+	return result.Doc, nil
 }
 
 // GetTitle obtiene el título de la página
@@ -107,29 +148,63 @@ func (h *HomePage) GetLinks() ([]string, error) {
 		return nil, err
 	}
 
-	links := make(map[string]bool)
-	
-	// Buscar enlaces en diferentes elementos
-	selectors := []string{"a[href]", "link[href]", "[src]"}
-	
-	for _, selector := range selectors {
-		doc.Find(selector).Each(func(_ int, s *goquery.Selection) {
-			if href, exists := s.Attr("href"); exists {
-				links[href] = true
-			}
-			if src, exists := s.Attr("src"); exists {
-				links[src] = true
-			}
-		})
+	return links.Extract(doc), nil
+}
+
+// Snapshot genera la instantánea normalizada de la estructura de la página
+func (h *HomePage) Snapshot() (*snapshot.Page, error) {
+	doc, err := h.fetchContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Capture(doc, "[id^='page_section']", links.Extract(doc)), nil
+}
+
+// CheckLinks verifica la accesibilidad de todos los enlaces de la página
+func (h *HomePage) CheckLinks(ctx context.Context, checker *links.Checker) (*links.Report, error) {
+	pageLinks, err := h.GetLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := checker.Check(ctx, h.URL, pageLinks)
+	if err != nil {
+		return nil, &PageError{"Error checking links", err}
+	}
+
+	return report, nil
+}
+
+// Lint ejecuta las comprobaciones estáticas de accesibilidad sobre la página
+func (h *HomePage) Lint() ([]lint.Issue, error) {
+	doc, err := h.fetchContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return lint.Check(doc), nil
+}
+
+// CheckSEO extrae y valida los metadatos SEO de la página
+func (h *HomePage) CheckSEO() (*seo.Metadata, []lint.Issue, error) {
+	doc, err := h.fetchContent()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Convertir el mapa a slice
-	uniqueLinks := make([]string, 0, len(links))
-	for link := range links {
-		uniqueLinks = append(uniqueLinks, link)
+	meta, issues := seo.Check(doc)
+	return meta, issues, nil
+}
+
+// AuditSecurity audita las cabeceras de seguridad, el certificado TLS y las cookies de la página
+func (h *HomePage) AuditSecurity() ([]lint.Issue, error) {
+	result, err := h.Fetch()
+	if err != nil {
+		return nil, err
 	}
 
-	return uniqueLinks, nil
+	return security.NewAuditor().Audit(result.URL, result.Header, result.Cookies, result.TLS), nil
 }
 
 // VerifyStructure verifica la estructura de la página
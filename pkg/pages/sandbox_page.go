@@ -8,6 +8,10 @@ import (
 	"time"
 	
 	"context"
+	"GoLang_FRT_E2E_Tests/pkg/consent"
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/storagestate"
+	"GoLang_FRT_E2E_Tests/pkg/visual"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
 )
@@ -23,8 +27,12 @@ func (e *PageError) SandboxError() string {
 
 // SandboxPage representa la página del sandbox de FRT
 type SandboxPage struct {
-	URL    string
-	client *http.Client
+	URL     string
+	Session emulation.Session
+	// Consent indica cómo responder al banner de cookies tras navegar; por defecto no se toca
+	Consent        consent.Decision
+	ConsentManager *consent.Manager
+	client         *http.Client
 }
 
 // NewSandboxPage crea una nueva instancia de HomePage
@@ -37,6 +45,30 @@ func NewSandboxPage() *SandboxPage {
 	}
 }
 
+// SetClient sustituye el cliente HTTP utilizado para descargar la página
+func (h *SandboxPage) SetClient(client *http.Client) {
+	h.client = client
+}
+
+// navigate aplica las opciones de emulación de la sesión, navega a la URL del sandbox
+// y responde al banner de cookies si se ha indicado una decisión
+func (h *SandboxPage) navigate() chromedp.Tasks {
+	tasks := append(h.Session.ChromedpActions(), chromedp.Navigate(h.URL))
+	if h.Consent.Action == consent.ActionNone {
+		return tasks
+	}
+	return append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+		manager := h.ConsentManager
+		if manager == nil {
+			var err error
+			if manager, err = consent.ManagerFromEnv(); err != nil {
+				return err
+			}
+		}
+		return manager.Chromedp(h.Consent, nil).Do(ctx)
+	}))
+}
+
 // fetchSandboxContent obtiene el contenido de la página
 func (h *SandboxPage) fetchSandboxContent() (*goquery.Document, error) {
 	req, err := http.NewRequest("GET", h.URL, nil)
@@ -191,7 +223,7 @@ func (h *SandboxPage) ClickDynamicButton() (string,error) {
     var hiddenText string
 
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
 		chromedp.WaitVisible(`button.btn.btn-primary`, chromedp.ByQuery),
         chromedp.Click(`button.btn.btn-primary`, chromedp.NodeVisible),		
 		chromedp.WaitVisible(`#hidden-element`, chromedp.ByID),
@@ -220,7 +252,7 @@ func (h *SandboxPage) InsertTextInTextbox(text string) (string,error) {
     var insertedText string
 
 	err := chromedp.Run(ctx,
-		chromedp.Navigate(h.URL),
+		h.navigate(),
 		chromedp.WaitVisible(`#formBasicText`, chromedp.ByID),
 		chromedp.SetValue(`#formBasicText`, text, chromedp.ByID),
         chromedp.Value(`#formBasicText`, &insertedText, chromedp.ByID),        
@@ -245,7 +277,7 @@ func (h *SandboxPage) TestCheckboxesAndRadioButtons() (string,string,error) {
     var checkboxValue, radioValue string
     // Navegar a la URL y seleccionar los checkboxes y radio buttons
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
         // Seleccionar los checkboxes
         chromedp.WaitVisible(`#checkbox-0`, chromedp.ByID),
         chromedp.Click(`#checkbox-0`, chromedp.NodeVisible),
@@ -314,7 +346,7 @@ func (h *SandboxPage) ClickDropdowns() (string, string, error) {
     var firstDropdownValue, secondDropdownValue string
     // Navegar a la URL y seleccionar opciones en los dropdowns
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
         // Seleccionar opción en el primer dropdown
         chromedp.WaitVisible(`#formBasicSelect`, chromedp.ByID),
         chromedp.SetValue(`#formBasicSelect`, "Fútbol", chromedp.ByID),
@@ -350,7 +382,7 @@ func (h *SandboxPage) HandlePopup() (string, error) {
     var popupText string
 
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
         //Esperamos a que cargue el Sandbox y pulsamos el botón de 'Mostrar Popup'
         chromedp.WaitVisible(`#root > div > div:nth-child(5) > div > button`, chromedp.ByQuery),
         chromedp.Click(`#root > div > div:nth-child(5) > div > button`, chromedp.NodeVisible),
@@ -381,7 +413,7 @@ func (h *SandboxPage) InteractWithShadowDOM() (string, error) {
     // Navegar a la URL y acceder al Shadow DOM
     var shadowContent string
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
         chromedp.WaitVisible(`#shadow-root-example`, chromedp.ByID),
         chromedp.ActionFunc(func(ctx context.Context) error {
             // Acceder al shadow root
@@ -435,7 +467,7 @@ func (h *SandboxPage) InteractWithTables() (string, string, string, string, erro
 
     // Navegar a la URL y seleccionar opciones en los dropdowns
     err := chromedp.Run(ctx,
-        chromedp.Navigate(h.URL),
+        h.navigate(),
         // Inspeccionamos la tabla dinámica
         chromedp.WaitVisible(`#root > div > div:nth-child(7) > div > table`, chromedp.BySearch),
         chromedp.Evaluate(`document.querySelector('#root > div > div:nth-child(7) > div > table').rows[1].cells[1].innerText`, &dynamicCellValueBefore),
@@ -462,3 +494,50 @@ func (h *SandboxPage) InteractWithTables() (string, string, string, string, erro
 
     return dynamicCellValueBefore, dynamicCellValueAfter, staticCellValueBefore, staticCellValueAfter, nil
 }
+
+// CaptureScreenshot captura la página completa (o el elemento indicado) enmascarando las zonas dinámicas
+func (h *SandboxPage) CaptureScreenshot(selector string, masks ...string) ([]byte, error) {
+    ctx, cancel := chromedp.NewContext(context.Background())
+    defer cancel()
+
+    ctx, cancel = context.WithTimeout(ctx, 15*time.Second)
+    defer cancel()
+
+    var screenshot []byte
+    err := chromedp.Run(ctx,
+        chromedp.EmulateViewport(1280, 800),
+        h.navigate(),
+        chromedp.WaitVisible(`#root > div > div:nth-child(8) > div > table`, chromedp.BySearch),
+        visual.CaptureChromedp(selector, masks, &screenshot),
+    )
+    if err != nil {
+        return nil, &PageError{"Error capturando la pantalla", err}
+    }
+
+    return screenshot, nil
+}
+
+// SaveStorageState navega al sandbox (respondiendo al banner si se ha indicado una decisión)
+// y guarda las cookies y el almacenamiento resultantes en un fichero JSON
+func (h *SandboxPage) SaveStorageState(file string) (*storagestate.State, error) {
+    ctx, cancel := chromedp.NewContext(context.Background())
+    defer cancel()
+
+    ctx, cancel = context.WithTimeout(ctx, 15*time.Second)
+    defer cancel()
+
+    state := &storagestate.State{}
+    err := chromedp.Run(ctx,
+        h.navigate(),
+        chromedp.WaitReady(`body`, chromedp.ByQuery),
+        storagestate.CaptureChromedp(state),
+    )
+    if err != nil {
+        return nil, &PageError{"Error capturando el estado de almacenamiento", err}
+    }
+    if err := state.Save(file); err != nil {
+        return nil, &PageError{"Error guardando el estado de almacenamiento", err}
+    }
+
+    return state, nil
+}
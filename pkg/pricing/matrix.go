@@ -0,0 +1,204 @@
+// pkg/pricing/matrix.go
+
+// Package pricing define la matriz de escenarios de búsqueda de Avis (ubicaciones ×
+// rangos de fechas × duraciones), guarda los precios de cada ejecución en un fichero
+// JSON lines y compara ejecuciones para detectar cambios y anomalías.
+package pricing
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dateLayout es el formato de las fechas absolutas de la matriz
+const dateLayout = "2006-01-02"
+
+// Route representa una oficina de recogida y, opcionalmente, otra de devolución
+type Route struct {
+	Pickup string `yaml:"pickup"`
+	Return string `yaml:"return"`
+}
+
+// DateRange define las fechas de recogida: desde From hasta To cada Every días.
+// From y To admiten fechas absolutas (2026-11-06) o relativas a hoy (+7d).
+type DateRange struct {
+	From  string `yaml:"from"`
+	To    string `yaml:"to"`
+	Every int    `yaml:"every"`
+}
+
+// Matrix es la definición YAML de los escenarios a vigilar
+type Matrix struct {
+	PickupHour int         `yaml:"pickup_hour"`
+	ReturnHour int         `yaml:"return_hour"`
+	Timezone   string      `yaml:"timezone"`
+	Locations  []Route     `yaml:"locations"`
+	DateRanges []DateRange `yaml:"date_ranges"`
+	Lengths    []int       `yaml:"lengths"`
+}
+
+// Scenario es una combinación concreta de la matriz
+type Scenario struct {
+	ID             string
+	PickupLocation string
+	ReturnLocation string
+	PickupTime     time.Time
+	ReturnTime     time.Time
+	Days           int
+}
+
+// LoadMatrix lee la matriz desde un fichero YAML
+func LoadMatrix(path string) (*Matrix, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseMatrix(f)
+}
+
+// ParseMatrix interpreta y valida una matriz en YAML
+func ParseMatrix(r io.Reader) (*Matrix, error) {
+	matrix := &Matrix{PickupHour: 10}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(matrix); err != nil {
+		return nil, fmt.Errorf("matriz YAML inválida: %w", err)
+	}
+
+	if matrix.ReturnHour == 0 {
+		matrix.ReturnHour = matrix.PickupHour
+	}
+	if len(matrix.Locations) == 0 || len(matrix.DateRanges) == 0 || len(matrix.Lengths) == 0 {
+		return nil, fmt.Errorf("la matriz necesita locations, date_ranges y lengths")
+	}
+	for _, route := range matrix.Locations {
+		if strings.TrimSpace(route.Pickup) == "" {
+			return nil, fmt.Errorf("ubicación sin oficina de recogida")
+		}
+	}
+	for _, length := range matrix.Lengths {
+		if length <= 0 {
+			return nil, fmt.Errorf("duración inválida: %d días", length)
+		}
+	}
+	if _, err := matrix.location(); err != nil {
+		return nil, err
+	}
+
+	return matrix, nil
+}
+
+// location devuelve la zona horaria de la matriz (Europe/Madrid por defecto)
+func (m *Matrix) location() (*time.Location, error) {
+	name := m.Timezone
+	if name == "" {
+		name = "Europe/Madrid"
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("zona horaria inválida %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Scenarios expande la matriz en escenarios concretos tomando now como referencia de
+// las fechas relativas. Se descartan las recogidas que ya han pasado.
+func (m *Matrix) Scenarios(now time.Time) ([]Scenario, error) {
+	loc, err := m.location()
+	if err != nil {
+		return nil, err
+	}
+	today := time.Date(now.In(loc).Year(), now.In(loc).Month(), now.In(loc).Day(), 0, 0, 0, 0, loc)
+
+	var scenarios []Scenario
+	for _, dates := range m.DateRanges {
+		from, err := resolveDate(dates.From, today)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if dates.To != "" {
+			if to, err = resolveDate(dates.To, today); err != nil {
+				return nil, err
+			}
+		}
+		every := dates.Every
+		if every <= 0 {
+			every = 7
+		}
+
+		for day := from; !day.After(to); day = day.AddDate(0, 0, every) {
+			pickup := time.Date(day.Year(), day.Month(), day.Day(), m.PickupHour, 0, 0, 0, loc)
+			if !pickup.After(now) {
+				continue
+			}
+			for _, route := range m.Locations {
+				for _, length := range m.Lengths {
+					end := time.Date(day.Year(), day.Month(), day.Day()+length, m.ReturnHour, 0, 0, 0, loc)
+					scenarios = append(scenarios, Scenario{
+						ID:             scenarioID(route, day, length),
+						PickupLocation: route.Pickup,
+						ReturnLocation: route.Return,
+						PickupTime:     pickup,
+						ReturnTime:     end,
+						Days:           length,
+					})
+				}
+			}
+		}
+	}
+
+	return scenarios, nil
+}
+
+// resolveDate interpreta una fecha absoluta o un desplazamiento "+Nd" desde hoy
+func resolveDate(value string, today time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "+") && strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(value, "+"), "d"))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("fecha relativa inválida %q", value)
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	date, err := time.ParseInLocation(dateLayout, value, today.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fecha inválida %q: %w", value, err)
+	}
+	return date, nil
+}
+
+// scenarioID construye un identificador estable para comparar el mismo escenario entre ejecuciones
+func scenarioID(route Route, day time.Time, length int) string {
+	id := slug(route.Pickup)
+	if route.Return != "" {
+		id += ">" + slug(route.Return)
+	}
+	return fmt.Sprintf("%s|%s|%dd", id, day.Format(dateLayout), length)
+}
+
+// slug reduce un nombre de oficina a minúsculas y guiones
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,174 @@
+// pkg/pricing/report.go
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Tipos de anomalía detectados al comparar ejecuciones
+const (
+	AnomalyPriceJump   = "price-jump"
+	AnomalyMissing     = "missing-price"
+	AnomalyDisappeared = "disappeared"
+	AnomalyInverted    = "pay-now-above-pay-later"
+	AnomalySearchError = "search-error"
+)
+
+// Options configura la detección de anomalías
+type Options struct {
+	// JumpThreshold es la variación relativa (0.25 = 25 %) a partir de la cual un cambio es anómalo
+	JumpThreshold float64
+}
+
+// DefaultOptions devuelve la configuración por defecto
+func DefaultOptions() Options {
+	return Options{JumpThreshold: 0.25}
+}
+
+// Change es la variación de precio de un vehículo entre dos ejecuciones
+type Change struct {
+	Key      string
+	Scenario string
+	Model    string
+	Before   float64
+	After    float64
+	Currency string
+}
+
+// Delta devuelve la variación relativa del precio
+func (c Change) Delta() float64 {
+	if c.Before == 0 {
+		return math.Inf(1)
+	}
+	return (c.After - c.Before) / c.Before
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %.2f → %.2f %s (%+.1f%%)", c.Scenario, c.Model, c.Before, c.After, c.Currency, c.Delta()*100)
+}
+
+// Anomaly es un resultado sospechoso que conviene revisar
+type Anomaly struct {
+	Kind     string
+	Scenario string
+	Model    string
+	Message  string
+}
+
+func (a Anomaly) String() string {
+	if a.Model == "" {
+		return fmt.Sprintf("[%s] %s: %s", a.Kind, a.Scenario, a.Message)
+	}
+	return fmt.Sprintf("[%s] %s %s: %s", a.Kind, a.Scenario, a.Model, a.Message)
+}
+
+// Report resume la comparación de una ejecución con la anterior
+type Report struct {
+	Previous  string
+	Latest    string
+	Changes   []Change
+	Appeared  []Record
+	Anomalies []Anomaly
+}
+
+// Compare compara la ejecución latest con previous (que puede ser nil en la primera ejecución)
+func Compare(previous, latest *Run, opts Options) *Report {
+	report := &Report{Latest: latest.ID}
+	current := indexRecords(latest.Records)
+
+	// Anomalías que no dependen de la ejecución anterior
+	for _, record := range latest.Records {
+		switch {
+		case record.Error != "":
+			report.Anomalies = append(report.Anomalies, Anomaly{AnomalySearchError, record.Scenario, "", record.Error})
+		case record.Available && record.Price() == 0:
+			report.Anomalies = append(report.Anomalies, Anomaly{AnomalyMissing, record.Scenario, record.Model, "disponible pero sin precio"})
+		case record.PayNow > 0 && record.PayLater > 0 && record.PayNow > record.PayLater:
+			report.Anomalies = append(report.Anomalies, Anomaly{AnomalyInverted, record.Scenario, record.Model,
+				fmt.Sprintf("pagar ahora %.2f > pagar después %.2f", record.PayNow, record.PayLater)})
+		}
+	}
+
+	if previous == nil {
+		return report
+	}
+	report.Previous = previous.ID
+	before := indexRecords(previous.Records)
+	searched := make(map[string]bool)
+	for _, record := range latest.Records {
+		searched[record.Scenario] = record.Error == ""
+	}
+
+	for key, old := range before {
+		record, ok := current[key]
+		if !ok {
+			// Solo es sospechoso si el escenario se ha podido buscar en esta ejecución
+			if searched[old.Scenario] {
+				report.Anomalies = append(report.Anomalies, Anomaly{AnomalyDisappeared, old.Scenario, old.Model, "ya no aparece en los resultados"})
+			}
+			continue
+		}
+		if old.Price() == 0 || record.Price() == 0 || old.Price() == record.Price() {
+			continue
+		}
+		change := Change{Key: key, Scenario: record.Scenario, Model: record.Model, Before: old.Price(), After: record.Price(), Currency: record.Currency}
+		report.Changes = append(report.Changes, change)
+		if math.Abs(change.Delta()) >= opts.JumpThreshold {
+			report.Anomalies = append(report.Anomalies, Anomaly{AnomalyPriceJump, change.Scenario, change.Model,
+				fmt.Sprintf("variación de %+.1f%%", change.Delta()*100)})
+		}
+	}
+	for key, record := range current {
+		if _, ok := before[key]; !ok {
+			report.Appeared = append(report.Appeared, record)
+		}
+	}
+
+	sort.Slice(report.Changes, func(i, j int) bool { return report.Changes[i].Key < report.Changes[j].Key })
+	sort.Slice(report.Appeared, func(i, j int) bool { return report.Appeared[i].Key() < report.Appeared[j].Key() })
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		a, b := report.Anomalies[i], report.Anomalies[j]
+		if a.Scenario != b.Scenario {
+			return a.Scenario < b.Scenario
+		}
+		return a.Model < b.Model
+	})
+	return report
+}
+
+// indexRecords indexa los registros con vehículo por su clave
+func indexRecords(records []Record) map[string]Record {
+	index := make(map[string]Record)
+	for _, record := range records {
+		if record.Error == "" && record.Model != "" {
+			index[record.Key()] = record
+		}
+	}
+	return index
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	if r.Previous == "" {
+		fmt.Fprintf(&b, "Ejecución %s (sin ejecución anterior para comparar)\n", r.Latest)
+	} else {
+		fmt.Fprintf(&b, "Ejecución %s comparada con %s\n", r.Latest, r.Previous)
+	}
+
+	fmt.Fprintf(&b, "Cambios de precio: %d\n", len(r.Changes))
+	for _, change := range r.Changes {
+		fmt.Fprintf(&b, "  ~ %s\n", change)
+	}
+	fmt.Fprintf(&b, "Vehículos nuevos: %d\n", len(r.Appeared))
+	for _, record := range r.Appeared {
+		fmt.Fprintf(&b, "  + %s %s: %.2f %s\n", record.Scenario, record.Model, record.Price(), record.Currency)
+	}
+	fmt.Fprintf(&b, "Anomalías: %d\n", len(r.Anomalies))
+	for _, anomaly := range r.Anomalies {
+		fmt.Fprintf(&b, "  ! %s\n", anomaly)
+	}
+	return b.String()
+}
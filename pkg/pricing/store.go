@@ -0,0 +1,144 @@
+// pkg/pricing/store.go
+package pricing
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record es el precio de un vehículo en un escenario y una ejecución. Si la búsqueda
+// del escenario falla se guarda un único registro con Error y sin vehículo.
+type Record struct {
+	RunID          string    `json:"run_id"`
+	Timestamp      time.Time `json:"timestamp"`
+	Scenario       string    `json:"scenario"`
+	PickupLocation string    `json:"pickup_location"`
+	ReturnLocation string    `json:"return_location,omitempty"`
+	PickupTime     time.Time `json:"pickup_time"`
+	ReturnTime     time.Time `json:"return_time"`
+	Model          string    `json:"model,omitempty"`
+	Category       string    `json:"category,omitempty"`
+	ACRISS         string    `json:"acriss,omitempty"`
+	PayNow         float64   `json:"pay_now,omitempty"`
+	PayLater       float64   `json:"pay_later,omitempty"`
+	Currency       string    `json:"currency,omitempty"`
+	Available      bool      `json:"available"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// Key identifica el mismo vehículo en el mismo escenario entre ejecuciones
+func (r Record) Key() string {
+	if r.ACRISS != "" {
+		return r.Scenario + "|" + r.ACRISS + "|" + r.Model
+	}
+	return r.Scenario + "|" + r.Model
+}
+
+// Price devuelve el menor de los precios registrados
+func (r Record) Price() float64 {
+	switch {
+	case r.PayNow == 0:
+		return r.PayLater
+	case r.PayLater == 0, r.PayNow <= r.PayLater:
+		return r.PayNow
+	default:
+		return r.PayLater
+	}
+}
+
+// Run agrupa los registros de una ejecución
+type Run struct {
+	ID      string
+	Started time.Time
+	Records []Record
+}
+
+// Store es la serie temporal de precios en un fichero JSON lines
+type Store struct {
+	Path string
+}
+
+// NewStore crea un almacén en la ruta indicada
+func NewStore(path string) *Store {
+	return &Store{Path: path}
+}
+
+// NewRunID genera el identificador de una ejecución a partir de su hora de inicio
+func NewRunID(started time.Time) string {
+	return started.UTC().Format("20060102T150405Z")
+}
+
+// Append añade los registros al final del fichero
+func (s *Store) Append(records []Record) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Runs lee todas las ejecuciones almacenadas en orden de aparición
+func (s *Store) Runs() ([]Run, error) {
+	f, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var runs []Run
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", s.Path, line, err)
+		}
+		i, ok := index[record.RunID]
+		if !ok {
+			i = len(runs)
+			index[record.RunID] = i
+			runs = append(runs, Run{ID: record.RunID, Started: record.Timestamp})
+		}
+		runs[i].Records = append(runs[i].Records, record)
+	}
+	return runs, scanner.Err()
+}
+
+// LastTwo devuelve la penúltima y la última ejecución, o nil si no existen
+func (s *Store) LastTwo() (previous, latest *Run, err error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return nil, nil, err
+	}
+	if n := len(runs); n > 0 {
+		latest = &runs[n-1]
+		if n > 1 {
+			previous = &runs[n-2]
+		}
+	}
+	return previous, latest, nil
+}
@@ -0,0 +1,195 @@
+// pkg/replica/avis.go
+
+package replica
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+//go:embed avis
+var avisFiles embed.FS
+
+// Identificadores de los controles del formulario de búsqueda, para AvisOptions.FieldErrors
+const (
+	AvisPickupField     = "hire-search"
+	AvisReturnField     = "return-search"
+	AvisPickupDateField = "date-from-display"
+	AvisReturnDateField = "date-to-display"
+	AvisDriverAgeField  = "driver-age"
+)
+
+// AvisVehicle es un vehículo de la página de resultados de la réplica. Los precios son por día.
+type AvisVehicle struct {
+	Model     string `json:"model"`
+	Category  string `json:"category"`
+	Group     string `json:"group"`
+	ACRISS    string `json:"acriss"`
+	Automatic bool   `json:"automatic"`
+	Seats     int    `json:"seats"`
+	Doors     int    `json:"doors"`
+	Luggage   int    `json:"luggage"`
+	// MileageLimit son los kilómetros incluidos por día; 0 es kilometraje ilimitado
+	MileageLimit   int     `json:"mileageLimit"`
+	PayNowPerDay   float64 `json:"payNowPerDay"`
+	PayLaterPerDay float64 `json:"payLaterPerDay"`
+	Unavailable    bool    `json:"unavailable"`
+}
+
+// DefaultAvisVehicles son los vehículos que muestra la réplica si no se indican otros
+var DefaultAvisVehicles = []AvisVehicle{
+	{Model: "Fiat 500", Category: "Mini", Group: "A", ACRISS: "MCMR", Seats: 4, Doors: 3, Luggage: 1, PayNowPerDay: 31.5, PayLaterPerDay: 35.9},
+	{Model: "Seat Ibiza", Category: "Económico", Group: "B", ACRISS: "EDMR", Seats: 5, Doors: 5, Luggage: 2, PayNowPerDay: 36.2, PayLaterPerDay: 41},
+	{Model: "Volkswagen Golf", Category: "Compacto", Group: "C", ACRISS: "CDMR", Seats: 5, Doors: 5, Luggage: 2, PayNowPerDay: 44.75, PayLaterPerDay: 49.9},
+	{Model: "Toyota Corolla Hybrid", Category: "Compacto", Group: "D", ACRISS: "CDAR", Automatic: true, Seats: 5, Doors: 5, Luggage: 2, PayNowPerDay: 52.3, PayLaterPerDay: 58.6},
+	{Model: "Peugeot 3008", Category: "SUV", Group: "F", ACRISS: "IFMR", Seats: 5, Doors: 5, Luggage: 3, MileageLimit: 250, PayNowPerDay: 61.9, PayLaterPerDay: 68},
+	{Model: "Mercedes Clase C", Category: "Premium", Group: "H", ACRISS: "PDAR", Automatic: true, Seats: 5, Doors: 4, Luggage: 3, PayNowPerDay: 89.4, PayLaterPerDay: 97.5},
+	{Model: "Renault Trafic", Category: "Furgoneta", Group: "L", ACRISS: "FVMR", Seats: 9, Doors: 4, Luggage: 4, PayNowPerDay: 95, PayLaterPerDay: 104.9, Unavailable: true},
+}
+
+// DefaultAvisLocations son las oficinas que ofrece el autocompletado de la réplica
+var DefaultAvisLocations = []string{
+	"Madrid-Barajas Adolfo Suárez T1 y T4 - ESP",
+	"Madrid-Estación de Atocha - ESP",
+	"Madrid-Chamartín - ESP",
+	"Barcelona-El Prat T1 y T2 - ESP",
+	"Barcelona-Estación de Sants - ESP",
+	"Málaga-Costa del Sol Aeropuerto - ESP",
+	"Valencia Aeropuerto - ESP",
+	"Sevilla-Estación Santa Justa - ESP",
+	"Palma de Mallorca Aeropuerto - ESP",
+}
+
+// AvisOptions configura la réplica del buscador de Avis
+type AvisOptions struct {
+	// NoConsentBanner oculta el banner de cookies de Tealium
+	NoConsentBanner bool
+	// SuggestionDelay retrasa las respuestas del autocompletado de ubicaciones
+	SuggestionDelay time.Duration
+	// ResultsDelay retrasa la respuesta de la página de resultados
+	ResultsDelay time.Duration
+	// EmptyResults hace que la búsqueda no devuelva vehículos
+	EmptyResults bool
+	// Vehicles sustituye a DefaultAvisVehicles
+	Vehicles []AvisVehicle
+	// Locations sustituye a DefaultAvisLocations
+	Locations []string
+	// FieldErrors muestra, al buscar, el mensaje indicado junto al control con ese id
+	FieldErrors map[string]string
+	// BannerError muestra, al buscar, un aviso general que impide la búsqueda
+	BannerError string
+}
+
+// avisConfig es la configuración que leen los scripts de la réplica
+type avisConfig struct {
+	ConsentBanner bool              `json:"consentBanner"`
+	EmptyResults  bool              `json:"emptyResults"`
+	Vehicles      []AvisVehicle     `json:"vehicles"`
+	FieldErrors   map[string]string `json:"fieldErrors"`
+	BannerError   string            `json:"bannerError"`
+}
+
+// AvisHandler sirve la réplica del buscador y la página de resultados de Avis
+func AvisHandler(opts AvisOptions) http.Handler {
+	files, err := fs.Sub(avisFiles, "avis")
+	if err != nil {
+		panic(err)
+	}
+	static := http.FileServer(http.FS(files))
+
+	vehicles := opts.Vehicles
+	if vehicles == nil {
+		vehicles = DefaultAvisVehicles
+	}
+	locations := opts.Locations
+	if locations == nil {
+		locations = DefaultAvisLocations
+	}
+	config := avisConfig{
+		ConsentBanner: !opts.NoConsentBanner,
+		EmptyResults:  opts.EmptyResults,
+		Vehicles:      vehicles,
+		FieldErrors:   opts.FieldErrors,
+		BannerError:   opts.BannerError,
+	}
+	if config.FieldErrors == nil {
+		config.FieldErrors = map[string]string{}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config.js", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("window.avisConfig = " + string(data) + ";\n"))
+	})
+	mux.HandleFunc("/api/locations", func(w http.ResponseWriter, r *http.Request) {
+		if !wait(r, opts.SuggestionDelay) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(matchLocations(locations, r.URL.Query().Get("q")))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/results.html" && !wait(r, opts.ResultsDelay) {
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		static.ServeHTTP(w, r)
+	})
+	return mux
+}
+
+// NewAvisServer arranca un servidor local con la réplica de Avis; hay que cerrarlo con Close
+func NewAvisServer(opts AvisOptions) *httptest.Server {
+	return httptest.NewServer(AvisHandler(opts))
+}
+
+// wait espera el retardo indicado; devuelve false si el cliente abandona la petición
+func wait(r *http.Request, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(delay):
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// locationFolder elimina los acentos para comparar las consultas del autocompletado
+var locationFolder = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+
+// matchLocations devuelve las ubicaciones que contienen todas las palabras de la consulta,
+// sin tener en cuenta mayúsculas ni acentos. Con menos de dos letras no se sugiere nada.
+func matchLocations(locations []string, query string) []string {
+	words := strings.Fields(locationFolder.Replace(strings.ToLower(query)))
+	matches := []string{}
+	if len(strings.Join(words, "")) < 2 {
+		return matches
+	}
+	for _, location := range locations {
+		folded := locationFolder.Replace(strings.ToLower(location))
+		all := true
+		for _, word := range words {
+			if !strings.Contains(folded, word) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matches = append(matches, location)
+		}
+	}
+	return matches
+}
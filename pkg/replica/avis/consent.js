@@ -0,0 +1,43 @@
+// Banner de consentimiento de Tealium (consent prompt) como el de avis.es. La decisión se
+// guarda en la cookie CONSENTMGR y el banner no vuelve a mostrarse mientras exista.
+(function () {
+  const config = window.avisConfig || {};
+  if (!config.consentBanner || document.cookie.split('; ').some(c => c.startsWith('CONSENTMGR='))) return;
+
+  const categories = [
+    ['preferences_prompt_personalization', 'Personalización'],
+    ['preferences_prompt_analytics', 'Analítica'],
+    ['preferences_prompt_display_ads', 'Publicidad'],
+  ];
+  const modal = document.createElement('div');
+  modal.id = '__tealiumGDPRecModal';
+  modal.className = 'consent-modal';
+  modal.innerHTML = `
+    <div class="consent-modal__dialog" role="dialog" aria-label="Consentimiento de cookies">
+      <p>Utilizamos cookies propias y de terceros para mejorar tu experiencia.</p>
+      <div class="consent-modal__actions">
+        <button type="button" id="consent_prompt_accept" class="btn btn--primary">Aceptar todas</button>
+        <button type="button" id="consent_prompt_decline" class="btn">Rechazar</button>
+        <button type="button" id="consent_prompt_preferences" class="btn">Preferencias</button>
+      </div>
+      <div class="consent-modal__preferences" hidden>
+        ${categories.map(([id, label]) => `<label><input type="checkbox" id="${id}" checked> ${label}</label>`).join('')}
+        <button type="button" id="preferences_prompt_submit" class="btn btn--primary">Guardar preferencias</button>
+      </div>
+    </div>`;
+
+  const decide = value => {
+    document.cookie = 'CONSENTMGR=' + encodeURIComponent(value) + '; path=/; max-age=31536000; SameSite=Lax';
+    modal.remove();
+  };
+  modal.querySelector('#consent_prompt_accept').addEventListener('click', () => decide('consent:true'));
+  modal.querySelector('#consent_prompt_decline').addEventListener('click', () => decide('consent:false'));
+  modal.querySelector('#consent_prompt_preferences').addEventListener('click', () => {
+    modal.querySelector('.consent-modal__preferences').hidden = false;
+  });
+  modal.querySelector('#preferences_prompt_submit').addEventListener('click', () => {
+    const allowed = categories.filter(([id]) => modal.querySelector('#' + id).checked).map(([id]) => id.replace('preferences_prompt_', ''));
+    decide('consent:custom|' + allowed.join(','));
+  });
+  document.body.append(modal);
+})();
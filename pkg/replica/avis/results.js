@@ -0,0 +1,152 @@
+// Réplica de la página de resultados de avis.es: tarjetas de vehículos con un dato por línea,
+// filtros por categoría, cambio y precio, ordenación y contador de resultados.
+(function () {
+  const config = window.avisConfig || {};
+  const params = new URLSearchParams(window.location.search);
+  const filters = {categories: new Set(), transmissions: new Set(), min: null, max: null};
+  const list = document.querySelector('.results-list');
+  const count = document.querySelector('.results-count');
+  const applied = document.querySelector('.filters__applied');
+  const sort = document.getElementById('sort');
+
+  // Días de alquiler por tramos de 24 horas; se calcula en UTC para que un cambio de hora no sume un día
+  const utc = value => {
+    const [date, time] = (value || '').split('T');
+    const [year, month, day] = (date || '').split('-').map(Number);
+    const [hours, minutes] = (time || '00:00').split(':').map(Number);
+    return Date.UTC(year, month - 1, day, hours, minutes);
+  };
+  const days = Math.max(1, Math.ceil((utc(params.get('to')) - utc(params.get('from'))) / 86400000) || 1);
+  const price = amount => amount.toLocaleString('es-ES', {minimumFractionDigits: 2, maximumFractionDigits: 2, useGrouping: true}) + ' €';
+  const transmission = vehicle => vehicle.automatic ? 'Automático' : 'Manual';
+
+  // Tipo de vehículo elegido en el buscador: turismo es todo lo que no es SUV ni furgoneta
+  const type = params.get('type');
+  const typeMatches = vehicle => {
+    switch (type) {
+      case 'suv': return vehicle.category === 'SUV';
+      case 'van': return vehicle.category === 'Furgoneta';
+      case 'car': return !['SUV', 'Furgoneta'].includes(vehicle.category);
+      default: return true;
+    }
+  };
+  let vehicles = config.emptyResults ? [] : (config.vehicles || []).filter(typeMatches);
+  vehicles = vehicles.map((vehicle, index) => Object.assign({}, vehicle, {
+    rank: index,
+    payNow: vehicle.payNowPerDay * days,
+    payLater: vehicle.payLaterPerDay * days,
+  }));
+
+  document.querySelector('.search-summary').textContent =
+    `${params.get('pickup') || ''} → ${params.get('return') || ''} · ${days} ${days === 1 ? 'día' : 'días'}`;
+
+  function card(vehicle) {
+    const article = document.createElement('article');
+    article.className = 'vehicle' + (vehicle.unavailable ? ' vehicle--unavailable' : '');
+    const lines = [
+      `${vehicle.model} o similar`,
+      vehicle.category,
+      `Grupo ${vehicle.group}`,
+      vehicle.acriss,
+      transmission(vehicle),
+      `${vehicle.seats} plazas`,
+      `${vehicle.doors} puertas`,
+      `${vehicle.luggage} maletas`,
+      vehicle.mileageLimit ? `${vehicle.mileageLimit} km` : 'Kilometraje ilimitado',
+    ];
+    if (vehicle.unavailable) {
+      lines.push('No disponible');
+    } else {
+      lines.push('Pagar ahora', price(vehicle.payNow), 'Pagar después', price(vehicle.payLater));
+    }
+    lines.forEach((text, index) => {
+      const line = document.createElement(index === 0 ? 'h3' : 'p');
+      line.textContent = text;
+      article.append(line);
+    });
+    if (!vehicle.unavailable) {
+      const button = document.createElement('button');
+      button.type = 'button';
+      button.textContent = 'Seleccionar';
+      article.append(button);
+    }
+    return article;
+  }
+
+  function checkboxes(fieldset, values, selected) {
+    values.forEach(value => {
+      const label = document.createElement('label');
+      const input = document.createElement('input');
+      input.type = 'checkbox';
+      input.value = value;
+      input.addEventListener('change', () => {
+        if (input.checked) selected.add(value); else selected.delete(value);
+        render();
+      });
+      label.append(input, ' ' + value);
+      fieldset.append(label);
+    });
+  }
+
+  const unique = values => values.filter((value, index) => values.indexOf(value) === index);
+  checkboxes(document.querySelector('.filters__categories'), unique(vehicles.map(vehicle => vehicle.category)), filters.categories);
+  checkboxes(document.querySelector('.filters__transmission'), ['Manual', 'Automático'], filters.transmissions);
+
+  const priceInputs = ['price-min', 'price-max'].map(id => document.getElementById(id));
+  priceInputs.forEach(input => input.addEventListener('keydown', event => {
+    if (event.key !== 'Enter') return;
+    const [min, max] = priceInputs.map(field => field.value === '' ? null : Number(field.value));
+    filters.min = min;
+    filters.max = max;
+    render();
+  }));
+
+  document.getElementById('clear-filters').addEventListener('click', () => {
+    filters.categories.clear();
+    filters.transmissions.clear();
+    filters.min = filters.max = null;
+    document.querySelectorAll('.filters input[type=checkbox]').forEach(input => { input.checked = false; });
+    priceInputs.forEach(input => { input.value = ''; });
+    render();
+  });
+  sort.addEventListener('change', render);
+
+  function render() {
+    const lowest = vehicle => Math.min(vehicle.payNow, vehicle.payLater);
+    const visible = vehicles.filter(vehicle =>
+      (filters.categories.size === 0 || filters.categories.has(vehicle.category)) &&
+      (filters.transmissions.size === 0 || filters.transmissions.has(transmission(vehicle))) &&
+      (filters.min === null || lowest(vehicle) >= filters.min) &&
+      (filters.max === null || lowest(vehicle) <= filters.max));
+
+    visible.sort((a, b) => {
+      if (sort.value === 'recommended') return a.rank - b.rank;
+      if (a.unavailable !== b.unavailable) return a.unavailable ? 1 : -1;
+      return sort.value === 'price-asc' ? lowest(a) - lowest(b) : lowest(b) - lowest(a);
+    });
+
+    list.innerHTML = '';
+    if (visible.length === 0) {
+      const empty = document.createElement('p');
+      empty.className = 'results-empty';
+      empty.textContent = 'No hay vehículos disponibles para tu búsqueda.';
+      list.append(empty);
+    }
+    visible.forEach(vehicle => list.append(card(vehicle)));
+
+    count.textContent = `${visible.length} ${visible.length === 1 ? 'vehículo' : 'vehículos'}`;
+
+    applied.innerHTML = '';
+    const tags = [...filters.categories, ...filters.transmissions];
+    if (filters.min !== null || filters.max !== null) {
+      tags.push(`${filters.min === null ? 0 : filters.min} - ${filters.max === null ? '∞' : filters.max} €`);
+    }
+    tags.forEach(text => {
+      const item = document.createElement('li');
+      item.textContent = text;
+      applied.append(item);
+    });
+  }
+
+  render();
+})();
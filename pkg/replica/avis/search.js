@@ -0,0 +1,215 @@
+// Réplica del formulario de búsqueda de avis.es: autocompletado de ubicaciones, selectores
+// de fecha (con el marcado de Pikaday) y de hora (con el de jquery-timepicker) y validación.
+(function () {
+  const config = window.avisConfig || {};
+  const form = document.getElementById('getAQuote');
+  const $ = selector => form.querySelector(selector);
+  const state = {pickup: '', return: '', from: null, to: null};
+
+  const pad = n => String(n).padStart(2, '0');
+  const isoDate = d => d.getFullYear() + '-' + pad(d.getMonth() + 1) + '-' + pad(d.getDate());
+  const startOfDay = d => new Date(d.getFullYear(), d.getMonth(), d.getDate());
+  const addDays = (d, days) => new Date(d.getFullYear(), d.getMonth(), d.getDate() + days);
+
+  // Autocompletado: cada pulsación pide sugerencias al servidor y solo se pinta la última respuesta
+  function autocomplete(input, key) {
+    const list = input.parentElement.querySelector('ul');
+    let sequence = 0;
+    let timer = null;
+    const clear = () => { list.innerHTML = ''; };
+
+    input.addEventListener('input', () => {
+      state[key] = '';
+      clearTimeout(timer);
+      const query = input.value;
+      const current = ++sequence;
+      timer = setTimeout(async () => {
+        const response = await fetch('/api/locations?q=' + encodeURIComponent(query));
+        const locations = await response.json();
+        if (current !== sequence) return;
+        clear();
+        locations.forEach(location => {
+          const item = document.createElement('li');
+          const button = document.createElement('button');
+          button.type = 'button';
+          button.textContent = location;
+          button.addEventListener('click', () => {
+            input.value = location;
+            state[key] = location;
+            clear();
+          });
+          item.append(button);
+          list.append(item);
+        });
+      }, 150);
+    });
+  }
+
+  // Selector de fechas con el marcado de Pikaday: td.is-disabled y button[data-pika-*]
+  function datePicker(group, minDate, onSelect) {
+    const input = group.querySelector('input[id^="date-"]');
+    const container = group.querySelector('.booking-widget__date-picker-container');
+    const maxDate = addDays(startOfDay(new Date()), 330);
+    let view = startOfDay(new Date());
+    const months = ['enero', 'febrero', 'marzo', 'abril', 'mayo', 'junio', 'julio', 'agosto', 'septiembre', 'octubre', 'noviembre', 'diciembre'];
+
+    function render() {
+      const year = view.getFullYear();
+      const month = view.getMonth();
+      const first = new Date(year, month, 1);
+      const days = new Date(year, month + 1, 0).getDate();
+      const offset = (first.getDay() + 6) % 7;
+      const min = minDate();
+      let cells = '';
+      for (let i = 0; i < offset; i++) cells += '<td class="is-empty"></td>';
+      for (let day = 1; day <= days; day++) {
+        const date = new Date(year, month, day);
+        const disabled = date < min || date > maxDate;
+        cells += `<td class="${disabled ? 'is-disabled' : ''}" data-day="${day}">` +
+          `<button class="pika-button pika-day" type="button" data-pika-year="${year}" data-pika-month="${month}" data-pika-day="${day}">${day}</button></td>`;
+        if ((offset + day) % 7 === 0) cells += '</tr><tr>';
+      }
+      container.innerHTML = `<div class="pika-single"><div class="pika-lendar">
+        <div class="pika-title"><div class="pika-label">${months[month]} ${year}</div>
+          <button class="pika-prev" type="button">Mes anterior</button>
+          <button class="pika-next" type="button">Mes siguiente</button></div>
+        <table class="pika-table"><thead><tr><th>L</th><th>M</th><th>X</th><th>J</th><th>V</th><th>S</th><th>D</th></tr></thead>
+        <tbody><tr>${cells}</tr></tbody></table></div></div>`;
+
+      container.querySelector('.pika-prev').addEventListener('click', () => { view = new Date(year, month - 1, 1); render(); });
+      container.querySelector('.pika-next').addEventListener('click', () => { view = new Date(year, month + 1, 1); render(); });
+      container.querySelectorAll('td:not(.is-disabled) > button').forEach(button => {
+        button.addEventListener('click', () => {
+          const date = new Date(+button.dataset.pikaYear, +button.dataset.pikaMonth, +button.dataset.pikaDay);
+          input.value = date.toLocaleDateString('es-ES', {weekday: 'short', day: 'numeric', month: 'short', year: 'numeric'});
+          container.classList.remove('booking-widget__date-picker-container--open');
+          onSelect(date);
+        });
+      });
+    }
+
+    input.addEventListener('click', () => {
+      const selected = onSelect.current && onSelect.current();
+      view = startOfDay(selected || minDate());
+      render();
+      container.classList.add('booking-widget__date-picker-container--open');
+    });
+  }
+
+  // Selector de horas con el marcado de jquery-timepicker: franjas de 30 minutos, deshabilitadas fuera de horario
+  function timePicker(group) {
+    const input = group.querySelector('input[id^="time-"]');
+    const container = group.querySelector('.booking-widget__time-picker-container');
+    const list = container.querySelector('ul');
+    input.addEventListener('click', () => {
+      list.innerHTML = '';
+      for (let minutes = 0; minutes < 24 * 60; minutes += 30) {
+        const label = pad(Math.floor(minutes / 60)) + ':' + pad(minutes % 60);
+        const item = document.createElement('li');
+        item.textContent = label;
+        if (minutes < 7 * 60 || minutes > 22 * 60 + 30) {
+          item.className = 'ui-timepicker-disabled';
+        } else {
+          item.addEventListener('click', () => {
+            input.value = label;
+            container.classList.remove('booking-widget__time-picker-container--open');
+          });
+        }
+        list.append(item);
+      }
+      container.classList.add('booking-widget__time-picker-container--open');
+    });
+  }
+
+  autocomplete($('#hire-search'), 'pickup');
+  autocomplete($('#return-search'), 'return');
+
+  $('#return-different').addEventListener('change', event => {
+    $('.standard-form__col--init-hidden').classList.toggle('is-visible', event.target.checked);
+    $('#different-return').value = String(event.target.checked);
+  });
+
+  const fromGroup = $('[data-field="from"]');
+  const toGroup = $('[data-field="to"]');
+  const selectFrom = date => { state.from = date; };
+  selectFrom.current = () => state.from;
+  const selectTo = date => { state.to = date; };
+  selectTo.current = () => state.to;
+  datePicker(fromGroup, () => startOfDay(new Date()), selectFrom);
+  datePicker(toGroup, () => state.from || startOfDay(new Date()), selectTo);
+  timePicker(fromGroup);
+  timePicker(toGroup);
+
+  const age = $('#driver-age');
+  for (let years = 18; years <= 99; years++) {
+    const option = document.createElement('option');
+    option.value = option.textContent = String(years);
+    option.selected = years === 30;
+    age.append(option);
+  }
+
+  $('#awd-toggle').addEventListener('click', () => {
+    $('#awd-block').hidden = false;
+    $('#awd-toggle').hidden = true;
+  });
+
+  // Validación del formulario: mensajes junto a cada campo y avisos generales
+  function showError(control, message) {
+    const error = document.createElement('p');
+    error.className = 'form-error';
+    error.textContent = message;
+    control.closest('.form-group, .standard-form__col').append(error);
+  }
+
+  function showBanner(message) {
+    const alert = document.createElement('div');
+    alert.className = 'alert--error';
+    alert.setAttribute('role', 'alert');
+    alert.textContent = message;
+    $('.booking-widget__alerts').append(alert);
+  }
+
+  const at = (date, time) => {
+    const [hours, minutes] = time.split(':').map(Number);
+    return new Date(date.getFullYear(), date.getMonth(), date.getDate(), hours, minutes);
+  };
+
+  form.addEventListener('submit', event => {
+    event.preventDefault();
+    form.querySelectorAll('.form-error').forEach(error => error.remove());
+    $('.booking-widget__alerts').innerHTML = '';
+
+    let errors = 0;
+    const fail = (control, message) => { showError(control, message); errors++; };
+    const different = $('#return-different').checked;
+
+    if (!state.pickup) fail($('#hire-search'), 'Introduce una oficina de recogida válida.');
+    if (different && !state.return) fail($('#return-search'), 'Introduce una oficina de devolución válida.');
+    if (!state.from) fail($('#date-from-display'), 'Selecciona la fecha de recogida.');
+    if (!state.to) fail($('#date-to-display'), 'Selecciona la fecha de devolución.');
+    if (state.from && state.to && at(state.to, $('#time-to-display').value) <= at(state.from, $('#time-from-display').value)) {
+      fail($('#date-to-display'), 'La fecha de devolución debe ser posterior a la de recogida.');
+    }
+    Object.entries(config.fieldErrors || {}).forEach(([id, message]) => {
+      const control = document.getElementById(id);
+      if (control) fail(control, message);
+    });
+    if (config.bannerError) {
+      showBanner(config.bannerError);
+      errors++;
+    }
+    if (errors > 0) return;
+
+    const params = new URLSearchParams({
+      pickup: state.pickup,
+      return: different ? state.return : state.pickup,
+      from: isoDate(state.from) + 'T' + $('#time-from-display').value,
+      to: isoDate(state.to) + 'T' + $('#time-to-display').value,
+      age: $('#driver-age').value,
+      residence: $('#residence').value,
+      awd: $('#awd').value,
+      type: $('#vehicle-type').value,
+    });
+    window.location.href = '/results.html?' + params.toString();
+  });
+})();
@@ -0,0 +1,103 @@
+// pkg/replica/sandbox.go
+
+// Package replica sirve réplicas locales de los sitios que se prueban, embebidas en el
+// binario, para ejecutar los page objects sin depender de que el sitio real esté
+// disponible. Las réplicas admiten variantes de fallo (elementos ausentes, renderizado
+// lento) para comprobar que los page objects informan bien de los errores.
+package replica
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+//go:embed sandbox
+var sandboxFiles embed.FS
+
+// Elementos del Sandbox que pueden omitirse con SandboxOptions.Missing
+const (
+	SandboxDynamicButton = "dynamic-button"
+	SandboxTextbox       = "textbox"
+	SandboxCheckboxes    = "checkboxes"
+	SandboxRadios        = "radios"
+	SandboxSelect        = "select"
+	SandboxDropdown      = "dropdown"
+	SandboxPopup         = "popup"
+	SandboxShadowDOM     = "shadow-dom"
+	SandboxDynamicTable  = "dynamic-table"
+	SandboxStaticTable   = "static-table"
+)
+
+// DefaultRevealDelay es lo que tarda en aparecer el elemento oculto tras pulsar el botón dinámico, como en el sitio real
+const DefaultRevealDelay = 3 * time.Second
+
+// SandboxOptions configura la réplica del Automation Sandbox
+type SandboxOptions struct {
+	// RenderDelay retrasa el renderizado de la aplicación tras cargar la página
+	RenderDelay time.Duration
+	// RevealDelay es el tiempo que tarda en mostrarse el elemento oculto; 0 usa DefaultRevealDelay
+	RevealDelay time.Duration
+	// Missing son los elementos que no se renderizan; su bloque se mantiene para no alterar la estructura
+	Missing []string
+	// ResponseDelay retrasa la respuesta del servidor a la página principal
+	ResponseDelay time.Duration
+}
+
+// sandboxConfig es la configuración que lee sandbox.js
+type sandboxConfig struct {
+	RenderDelay int64    `json:"renderDelay"`
+	RevealDelay int64    `json:"revealDelay"`
+	Missing     []string `json:"missing"`
+}
+
+// SandboxHandler sirve la réplica del Automation Sandbox con las opciones indicadas
+func SandboxHandler(opts SandboxOptions) http.Handler {
+	files, err := fs.Sub(sandboxFiles, "sandbox")
+	if err != nil {
+		panic(err)
+	}
+	static := http.FileServer(http.FS(files))
+
+	reveal := opts.RevealDelay
+	if reveal <= 0 {
+		reveal = DefaultRevealDelay
+	}
+	config := sandboxConfig{
+		RenderDelay: opts.RenderDelay.Milliseconds(),
+		RevealDelay: reveal.Milliseconds(),
+		Missing:     append([]string{}, opts.Missing...),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config.js", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("window.sandboxConfig = " + string(data) + ";\n"))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" && opts.ResponseDelay > 0 {
+			select {
+			case <-time.After(opts.ResponseDelay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		static.ServeHTTP(w, r)
+	})
+	return mux
+}
+
+// NewSandboxServer arranca un servidor local con la réplica del Sandbox; hay que cerrarlo con Close
+func NewSandboxServer(opts SandboxOptions) *httptest.Server {
+	return httptest.NewServer(SandboxHandler(opts))
+}
@@ -0,0 +1,139 @@
+// Réplica del Automation Sandbox de Free Range Testers. La estructura de #root reproduce
+// la del sitio real para que los selectores posicionales de SandboxPage funcionen igual.
+(function () {
+  const config = Object.assign({renderDelay: 0, revealDelay: 3000, missing: []}, window.sandboxConfig || {});
+  const missing = name => config.missing.includes(name);
+
+  const el = (tag, attrs, ...children) => {
+    const node = document.createElement(tag);
+    Object.entries(attrs || {}).forEach(([key, value]) => {
+      if (key === 'className') node.className = value;
+      else if (key.startsWith('on')) node.addEventListener(key.slice(2), value);
+      else node.setAttribute(key, value);
+    });
+    children.flat().forEach(child => node.append(child));
+    return node;
+  };
+  const section = (title, ...children) => el('div', {}, el('h3', {}, title), el('div', {}, ...children));
+
+  const randomId = () => 'btn-' + Math.random().toString(36).slice(2, 10);
+
+  function dynamicButton() {
+    if (missing('dynamic-button')) return section('Botón con ID dinámico');
+    const hidden = el('p', {id: 'hidden-element', style: 'display:none'},
+      'OMG, aparezco después de 3 segundos de haber hecho click en el botón 👻.');
+    const button = el('button', {type: 'button', className: 'btn btn-primary', id: randomId(), onclick: () => {
+      button.id = randomId();
+      setTimeout(() => { hidden.style.display = 'block'; }, config.revealDelay);
+    }}, 'Hacé click para generar un ID dinámico y mostrar el elemento oculto');
+    return section('Botón con ID dinámico', button, hidden);
+  }
+
+  function textbox() {
+    if (missing('textbox')) return section('Un aburrido texto');
+    return section('Un aburrido texto',
+      el('label', {for: 'formBasicText'}, 'Un aburrido texto'),
+      el('input', {type: 'text', id: 'formBasicText', className: 'form-control', placeholder: 'Ingresá texto'}));
+  }
+
+  function checkboxesAndRadios() {
+    const foods = ['Pasta 🍝', 'Pizza 🍕', 'Hamburguesa 🍔', 'Helado 🍧', 'Torta 🍰'];
+    const checkboxes = missing('checkboxes') ? [] : foods.map((food, i) => el('div', {className: 'form-check'},
+      el('input', {type: 'checkbox', id: 'checkbox-' + i, value: food}),
+      el('label', {for: 'checkbox-' + i}, food)));
+    const radios = missing('radios') ? [] : [['formRadio1', 'Si'], ['formRadio2', 'No']].map(([id, value]) =>
+      el('div', {className: 'form-check'},
+        el('input', {type: 'radio', id, name: 'formHorizontalRadios', value}),
+        el('label', {for: id}, value)));
+    return section('Checkboxes y radio buttons', checkboxes, el('p', {}, '¿Sos un robot?'), radios);
+  }
+
+  function dropdowns() {
+    const children = [];
+    if (!missing('select')) {
+      children.push(el('select', {id: 'formBasicSelect', className: 'form-select'},
+        el('option', {value: ''}, 'Seleccioná un deporte'),
+        ['Fútbol', 'Tennis', 'Basketball'].map(sport => el('option', {value: sport}, sport))));
+    }
+    if (!missing('dropdown')) {
+      const days = ['Lunes', 'Martes', 'Miércoles', 'Jueves', 'Viernes', 'Sábado', 'Domingo'];
+      const wrapper = el('div', {className: 'dropdown'});
+      // Como en react-bootstrap, el menú solo existe en el DOM mientras está abierto
+      const toggle = el('button', {type: 'button', id: 'dropdown-basic-button', className: 'dropdown-toggle btn btn-success',
+        onclick: () => {
+          const open = wrapper.querySelector('.dropdown-menu');
+          if (open) { open.remove(); return; }
+          wrapper.append(el('div', {className: 'dropdown-menu'}, days.map((day, i) =>
+            el('a', {href: '#/action-' + (i + 1), className: 'dropdown-item', onclick: event => {
+              event.preventDefault();
+              toggle.textContent = day;
+              wrapper.querySelector('.dropdown-menu').style.display = 'none';
+            }}, day))));
+        }}, 'Día de la semana');
+      wrapper.append(toggle);
+      children.push(wrapper);
+    }
+    return section('Dropdowns', children);
+  }
+
+  function popup() {
+    if (missing('popup')) return section('Popup');
+    const show = () => {
+      const modal = el('div', {className: 'fade modal show', role: 'dialog', style: 'display:block'},
+        el('div', {className: 'modal-dialog'},
+          el('div', {className: 'modal-content'},
+            el('div', {className: 'modal-header'}, el('div', {className: 'modal-title h4'}, 'Popup de ejemplo')),
+            el('div', {className: 'modal-body'}, '¿Viste? ¡Apareció un Pop-up!'),
+            el('div', {className: 'modal-footer'},
+              el('button', {type: 'button', className: 'btn btn-secondary', onclick: () => modal.remove()}, 'Cerrar')))));
+      document.body.append(modal);
+    };
+    return section('Popup', el('button', {type: 'button', className: 'btn btn-secondary', onclick: show}, 'Mostrar popup'));
+  }
+
+  function shadowDOM() {
+    if (missing('shadow-dom')) return section('Shadow DOM');
+    const host = el('div', {id: 'shadow-root-example'});
+    host.attachShadow({mode: 'open'}).append(el('p', {id: 'shadow-host'}, 'Este es un ejemplo de Shadow DOM'));
+    return section('Shadow DOM', host);
+  }
+
+  function table(headers, rows) {
+    return el('table', {className: 'table table-striped table-bordered'},
+      el('thead', {}, el('tr', {}, headers.map(h => el('th', {}, h)))),
+      el('tbody', {}, rows.map(row => el('tr', {}, row.map(cell => el('td', {}, String(cell)))))));
+  }
+
+  function dynamicTable() {
+    if (missing('dynamic-table')) return section('Tabla dinámica');
+    const random = () => Math.floor(Math.random() * 1000) + 1;
+    const rows = [0, 1, 2].map(() => [random(), random(), random()]);
+    return section('Tabla dinámica', table(['A', 'B', 'C'], rows));
+  }
+
+  function staticTable() {
+    if (missing('static-table')) return section('Tabla estática');
+    return section('Tabla estática', table(['Nombre', 'Edad', 'Ocupación'], [
+      ['Lucas', 30, 'Ingeniero'],
+      ['Mariana', 25, 'Diseñadora'],
+      ['Santiago', 35, 'Profesor'],
+    ]));
+  }
+
+  function render() {
+    const container = el('div', {className: 'container'},
+      el('div', {}, el('h1', {}, '🤖 Automation Sandbox')),
+      dynamicButton(),
+      textbox(),
+      checkboxesAndRadios(),
+      popup(),
+      dropdowns(),
+      dynamicTable(),
+      staticTable(),
+      shadowDOM());
+    document.getElementById('root').append(container);
+  }
+
+  if (config.renderDelay > 0) setTimeout(render, config.renderDelay);
+  else render();
+})();
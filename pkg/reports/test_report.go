@@ -17,6 +17,7 @@ type TestResult struct {
     Logs      []string
     Timestamp time.Time
     Duration  time.Duration
+    Images    []string
     SubTests  []*TestResult
 }
 
@@ -43,6 +44,7 @@ func GenerateHTMLReport(results []TestResult, outputPath string) error {
             .error { color: red; margin-top: 10px; }
             .subtest { margin-left: 20px; }
             .running { color: yellow; }
+            .visual img { max-width: 100%; border: 1px solid #ddd; margin-top: 10px; }
         </style>
     </head>
     <body>
@@ -57,6 +59,13 @@ func GenerateHTMLReport(results []TestResult, outputPath string) error {
                 {{.}}<br>
                 {{end}}
             </div>
+            {{if .Images}}
+            <div class="visual">
+                {{range .Images}}
+                <a href="{{.}}"><img src="{{.}}" alt="Esperada / Actual / Diferencia"></a>
+                {{end}}
+            </div>
+            {{end}}
             {{if .SubTests}}
             <div class="subtest">
                 {{range .SubTests}}
@@ -0,0 +1,233 @@
+// pkg/security/security.go
+
+// Package security audita las cabeceras HTTP de seguridad, el estado TLS y las
+// cookies devueltas al descargar una página.
+package security
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+)
+
+// Valores recomendados para la auditoría
+const (
+	DefaultMinHSTSMaxAge    = 180 * 24 * time.Hour
+	DefaultCertExpiryWindow = 30 * 24 * time.Hour
+)
+
+// referrerPolicies contiene los valores válidos de Referrer-Policy que no filtran la URL completa
+var referrerPolicies = map[string]bool{
+	"no-referrer":                     true,
+	"same-origin":                     true,
+	"origin":                          true,
+	"strict-origin":                   true,
+	"origin-when-cross-origin":        true,
+	"strict-origin-when-cross-origin": true,
+}
+
+// Auditor comprueba las cabeceras, el certificado y las cookies de una respuesta
+type Auditor struct {
+	MinHSTSMaxAge    time.Duration
+	CertExpiryWindow time.Duration
+	Now              func() time.Time
+}
+
+// NewAuditor crea un nuevo Auditor con los valores recomendados
+func NewAuditor() *Auditor {
+	return &Auditor{
+		MinHSTSMaxAge:    DefaultMinHSTSMaxAge,
+		CertExpiryWindow: DefaultCertExpiryWindow,
+		Now:              time.Now,
+	}
+}
+
+// Audit ejecuta todas las comprobaciones sobre la respuesta de pageURL
+func (a *Auditor) Audit(pageURL string, header http.Header, cookies []*http.Cookie, state *tls.ConnectionState) []lint.Issue {
+	u, err := url.Parse(pageURL)
+	https := err == nil && u.Scheme == "https"
+
+	var issues []lint.Issue
+	issues = append(issues, a.checkCSP(header)...)
+	issues = append(issues, a.checkFraming(header)...)
+	issues = append(issues, a.checkContentTypeOptions(header)...)
+	issues = append(issues, a.checkReferrerPolicy(header)...)
+	issues = append(issues, a.checkPermissionsPolicy(header)...)
+	if https {
+		issues = append(issues, a.checkHSTS(header)...)
+		issues = append(issues, a.checkTLS(state)...)
+	}
+	issues = append(issues, a.checkCookies(cookies, https)...)
+	return issues
+}
+
+func issue(rule string, severity lint.Severity, element, format string, args ...any) lint.Issue {
+	return lint.Issue{
+		Rule:     rule,
+		Severity: severity,
+		Element:  element,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// cspDirectives convierte la cabecera CSP en un mapa directiva -> fuentes
+func cspDirectives(header http.Header) map[string][]string {
+	directives := make(map[string][]string)
+	for _, policy := range header.Values("Content-Security-Policy") {
+		for _, directive := range strings.Split(policy, ";") {
+			fields := strings.Fields(strings.ToLower(directive))
+			if len(fields) == 0 {
+				continue
+			}
+			if _, exists := directives[fields[0]]; !exists {
+				directives[fields[0]] = fields[1:]
+			}
+		}
+	}
+	return directives
+}
+
+func (a *Auditor) checkCSP(header http.Header) []lint.Issue {
+	if header.Get("Content-Security-Policy") == "" {
+		return []lint.Issue{issue("csp", lint.SeverityError, "Content-Security-Policy", "Falta la cabecera")}
+	}
+
+	var issues []lint.Issue
+	directives := cspDirectives(header)
+	scriptSources, ok := directives["script-src"]
+	if !ok {
+		scriptSources, ok = directives["default-src"]
+	}
+	if !ok {
+		issues = append(issues, issue("csp", lint.SeverityWarning, "Content-Security-Policy", "No hay directiva script-src ni default-src"))
+	}
+	for _, source := range scriptSources {
+		if source == "'unsafe-inline'" || source == "'unsafe-eval'" || source == "*" {
+			issues = append(issues, issue("csp", lint.SeverityWarning, "Content-Security-Policy", "Los scripts permiten %s", source))
+		}
+	}
+	return issues
+}
+
+func (a *Auditor) checkFraming(header http.Header) []lint.Issue {
+	if _, ok := cspDirectives(header)["frame-ancestors"]; ok {
+		return nil
+	}
+
+	value := strings.ToUpper(strings.TrimSpace(header.Get("X-Frame-Options")))
+	switch value {
+	case "DENY", "SAMEORIGIN":
+		return nil
+	case "":
+		return []lint.Issue{issue("framing", lint.SeverityError, "X-Frame-Options", "Faltan X-Frame-Options y la directiva CSP frame-ancestors")}
+	default:
+		return []lint.Issue{issue("framing", lint.SeverityError, "X-Frame-Options", "Valor no válido %q", value)}
+	}
+}
+
+func (a *Auditor) checkContentTypeOptions(header http.Header) []lint.Issue {
+	if !strings.EqualFold(strings.TrimSpace(header.Get("X-Content-Type-Options")), "nosniff") {
+		return []lint.Issue{issue("content-type-options", lint.SeverityError, "X-Content-Type-Options", "Se esperaba nosniff y se ha recibido %q", header.Get("X-Content-Type-Options"))}
+	}
+	return nil
+}
+
+func (a *Auditor) checkReferrerPolicy(header http.Header) []lint.Issue {
+	value := strings.TrimSpace(header.Get("Referrer-Policy"))
+	if value == "" {
+		return []lint.Issue{issue("referrer-policy", lint.SeverityWarning, "Referrer-Policy", "Falta la cabecera")}
+	}
+
+	// Si hay varios valores el navegador aplica el último que reconoce
+	policies := strings.Split(value, ",")
+	policy := strings.ToLower(strings.TrimSpace(policies[len(policies)-1]))
+	if !referrerPolicies[policy] {
+		return []lint.Issue{issue("referrer-policy", lint.SeverityWarning, "Referrer-Policy", "La política %q puede filtrar las URLs completas", policy)}
+	}
+	return nil
+}
+
+func (a *Auditor) checkPermissionsPolicy(header http.Header) []lint.Issue {
+	if strings.TrimSpace(header.Get("Permissions-Policy")) == "" {
+		return []lint.Issue{issue("permissions-policy", lint.SeverityWarning, "Permissions-Policy", "Falta la cabecera")}
+	}
+	return nil
+}
+
+func (a *Auditor) checkHSTS(header http.Header) []lint.Issue {
+	value := header.Get("Strict-Transport-Security")
+	if value == "" {
+		return []lint.Issue{issue("hsts", lint.SeverityError, "Strict-Transport-Security", "Falta la cabecera")}
+	}
+
+	for _, directive := range strings.Split(value, ";") {
+		key, raw, found := strings.Cut(strings.TrimSpace(directive), "=")
+		if !found || !strings.EqualFold(key, "max-age") {
+			continue
+		}
+		seconds, err := strconv.ParseInt(strings.Trim(raw, `"`), 10, 64)
+		if err != nil {
+			return []lint.Issue{issue("hsts", lint.SeverityError, "Strict-Transport-Security", "max-age no válido %q", raw)}
+		}
+		if maxAge := time.Duration(seconds) * time.Second; maxAge < a.MinHSTSMaxAge {
+			return []lint.Issue{issue("hsts", lint.SeverityWarning, "Strict-Transport-Security", "max-age %s es inferior a %s", maxAge, a.MinHSTSMaxAge)}
+		}
+		return nil
+	}
+	return []lint.Issue{issue("hsts", lint.SeverityError, "Strict-Transport-Security", "Falta max-age")}
+}
+
+func (a *Auditor) checkTLS(state *tls.ConnectionState) []lint.Issue {
+	if state == nil {
+		return []lint.Issue{issue("tls", lint.SeverityError, "TLS", "No hay estado de la conexión TLS")}
+	}
+
+	var issues []lint.Issue
+	if state.Version < tls.VersionTLS12 {
+		issues = append(issues, issue("tls", lint.SeverityError, "TLS", "Protocolo obsoleto %s", tls.VersionName(state.Version)))
+	}
+	if len(state.PeerCertificates) == 0 {
+		return append(issues, issue("tls", lint.SeverityError, "TLS", "No hay certificado del servidor"))
+	}
+
+	cert := state.PeerCertificates[0]
+	remaining := cert.NotAfter.Sub(a.Now())
+	switch {
+	case remaining <= 0:
+		issues = append(issues, issue("tls-certificate", lint.SeverityError, cert.Subject.CommonName, "El certificado caducó el %s", cert.NotAfter.Format(time.DateOnly)))
+	case remaining < a.CertExpiryWindow:
+		issues = append(issues, issue("tls-certificate", lint.SeverityWarning, cert.Subject.CommonName, "El certificado caduca el %s", cert.NotAfter.Format(time.DateOnly)))
+	}
+	return issues
+}
+
+func (a *Auditor) checkCookies(cookies []*http.Cookie, https bool) []lint.Issue {
+	var issues []lint.Issue
+	for _, cookie := range cookies {
+		element := "cookie " + cookie.Name
+		// En HTTPS la falta de Secure se informa una sola vez, también cuando SameSite=None la exige
+		missingSecure := https && !cookie.Secure
+		if missingSecure {
+			issues = append(issues, issue("cookie", lint.SeverityError, element, "Falta el flag Secure"))
+		}
+		if !cookie.HttpOnly {
+			issues = append(issues, issue("cookie", lint.SeverityWarning, element, "Falta el flag HttpOnly"))
+		}
+		// Una cookie sin atributo SameSite se interpreta con valor cero
+		switch cookie.SameSite {
+		case 0, http.SameSiteDefaultMode:
+			issues = append(issues, issue("cookie", lint.SeverityWarning, element, "Falta el atributo SameSite"))
+		case http.SameSiteNoneMode:
+			if !cookie.Secure && !missingSecure {
+				issues = append(issues, issue("cookie", lint.SeverityError, element, "SameSite=None requiere Secure"))
+			}
+		}
+	}
+	return issues
+}
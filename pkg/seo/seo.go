@@ -0,0 +1,299 @@
+// pkg/seo/seo.go
+
+// Package seo extrae y valida los metadatos SEO de un documento goquery
+package seo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Límites de longitud recomendados para los resultados de búsqueda
+const (
+	MaxTitleLength       = 60
+	MinDescriptionLength = 50
+	MaxDescriptionLength = 160
+)
+
+// Alternate representa un enlace hreflang alternativo
+type Alternate struct {
+	Lang string
+	Href string
+}
+
+// StructuredData representa un bloque JSON-LD
+type StructuredData struct {
+	Raw   string
+	Data  any
+	Err   error
+	Types []string
+}
+
+// Metadata contiene los metadatos SEO de la página
+type Metadata struct {
+	Title          []string
+	Description    []string
+	Canonical      []string
+	Robots         []string
+	OpenGraph      map[string][]string
+	Twitter        map[string][]string
+	Alternates     []Alternate
+	StructuredData []StructuredData
+}
+
+// Extract obtiene los metadatos SEO del documento
+func Extract(doc *goquery.Document) *Metadata {
+	meta := &Metadata{
+		OpenGraph: make(map[string][]string),
+		Twitter:   make(map[string][]string),
+	}
+
+	doc.Find("head title").Each(func(_ int, s *goquery.Selection) {
+		meta.Title = append(meta.Title, strings.TrimSpace(s.Text()))
+	})
+
+	doc.Find("meta").Each(func(_ int, s *goquery.Selection) {
+		content, _ := s.Attr("content")
+		content = strings.TrimSpace(content)
+		name, _ := s.Attr("name")
+		property, _ := s.Attr("property")
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			key = strings.ToLower(strings.TrimSpace(property))
+		}
+
+		switch {
+		case key == "description":
+			meta.Description = append(meta.Description, content)
+		case key == "robots":
+			meta.Robots = append(meta.Robots, content)
+		case strings.HasPrefix(key, "og:"):
+			meta.OpenGraph[key] = append(meta.OpenGraph[key], content)
+		case strings.HasPrefix(key, "twitter:"):
+			meta.Twitter[key] = append(meta.Twitter[key], content)
+		}
+	})
+
+	doc.Find("link[rel]").Each(func(_ int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		href, _ := s.Attr("href")
+		href = strings.TrimSpace(href)
+		for _, r := range strings.Fields(strings.ToLower(rel)) {
+			switch r {
+			case "canonical":
+				meta.Canonical = append(meta.Canonical, href)
+			case "alternate":
+				if lang, ok := s.Attr("hreflang"); ok {
+					meta.Alternates = append(meta.Alternates, Alternate{Lang: strings.TrimSpace(lang), Href: href})
+				}
+			}
+		}
+	})
+
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, s *goquery.Selection) {
+		raw := strings.TrimSpace(s.Text())
+		data := StructuredData{Raw: raw}
+		data.Err = json.Unmarshal([]byte(raw), &data.Data)
+		if data.Err == nil {
+			data.Types = structuredTypes(data.Data)
+		}
+		meta.StructuredData = append(meta.StructuredData, data)
+	})
+
+	return meta
+}
+
+// NoIndex indica si la página pide no ser indexada
+func (m *Metadata) NoIndex() bool {
+	for _, robots := range m.Robots {
+		for _, directive := range strings.Split(strings.ToLower(robots), ",") {
+			if d := strings.TrimSpace(directive); d == "noindex" || d == "none" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hreflangPattern acepta códigos como "es", "es-ES", "zh-Hant-TW" o "x-default"
+var hreflangPattern = regexp.MustCompile(`^(?i)(x-default|[a-z]{2,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?)$`)
+
+// Validate comprueba los metadatos y devuelve los problemas encontrados
+func Validate(meta *Metadata) []lint.Issue {
+	var issues []lint.Issue
+	add := func(rule string, severity lint.Severity, element, format string, args ...any) {
+		issues = append(issues, lint.Issue{
+			Rule:     rule,
+			Severity: severity,
+			Element:  element,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Título
+	switch {
+	case len(meta.Title) == 0 || meta.Title[0] == "":
+		add("seo-title", lint.SeverityError, "title", "Falta el título")
+	case len(meta.Title) > 1:
+		add("seo-title", lint.SeverityError, "title", "Título duplicado (%d encontrados)", len(meta.Title))
+	case length(meta.Title[0]) > MaxTitleLength:
+		add("seo-title", lint.SeverityWarning, "title", "El título tiene %d caracteres, máximo %d", length(meta.Title[0]), MaxTitleLength)
+	}
+
+	// Meta description
+	switch {
+	case len(meta.Description) == 0 || meta.Description[0] == "":
+		add("seo-description", lint.SeverityError, `meta[name="description"]`, "Falta la meta description")
+	case len(meta.Description) > 1:
+		add("seo-description", lint.SeverityError, `meta[name="description"]`, "Meta description duplicada (%d encontradas)", len(meta.Description))
+	case length(meta.Description[0]) > MaxDescriptionLength:
+		add("seo-description", lint.SeverityWarning, `meta[name="description"]`, "La descripción tiene %d caracteres, máximo %d", length(meta.Description[0]), MaxDescriptionLength)
+	case length(meta.Description[0]) < MinDescriptionLength:
+		add("seo-description", lint.SeverityWarning, `meta[name="description"]`, "La descripción tiene %d caracteres, mínimo %d", length(meta.Description[0]), MinDescriptionLength)
+	}
+
+	// URL canónica
+	switch {
+	case len(meta.Canonical) == 0:
+		add("seo-canonical", lint.SeverityWarning, `link[rel="canonical"]`, "Falta la URL canónica")
+	case len(meta.Canonical) > 1:
+		add("seo-canonical", lint.SeverityError, `link[rel="canonical"]`, "URL canónica duplicada (%d encontradas)", len(meta.Canonical))
+	case !isAbsolute(meta.Canonical[0]):
+		add("seo-canonical", lint.SeverityError, `link[rel="canonical"]`, "La URL canónica no es absoluta: %q", meta.Canonical[0])
+	}
+
+	// Meta robots
+	if len(meta.Robots) > 1 {
+		add("seo-robots", lint.SeverityError, `meta[name="robots"]`, "Meta robots duplicada (%d encontradas)", len(meta.Robots))
+	}
+	if meta.NoIndex() {
+		add("seo-robots", lint.SeverityWarning, `meta[name="robots"]`, "La página está marcada como noindex")
+	}
+
+	// OpenGraph
+	for _, property := range []string{"og:title", "og:type", "og:image", "og:url"} {
+		if values := meta.OpenGraph[property]; len(values) == 0 || values[0] == "" {
+			add("seo-opengraph", lint.SeverityWarning, property, "Falta la propiedad OpenGraph")
+		}
+	}
+	for _, property := range []string{"og:title", "og:type", "og:url", "og:description"} {
+		if values := meta.OpenGraph[property]; len(values) > 1 {
+			add("seo-opengraph", lint.SeverityError, property, "Propiedad OpenGraph duplicada (%d encontradas)", len(values))
+		}
+	}
+	if values := meta.OpenGraph["og:description"]; len(values) == 1 && length(values[0]) > MaxDescriptionLength*2 {
+		add("seo-opengraph", lint.SeverityWarning, "og:description", "La descripción OpenGraph tiene %d caracteres", length(values[0]))
+	}
+
+	// Twitter cards
+	cards := meta.Twitter["twitter:card"]
+	switch {
+	case len(cards) == 0:
+		add("seo-twitter", lint.SeverityWarning, "twitter:card", "Falta la Twitter card")
+	case len(cards) > 1:
+		add("seo-twitter", lint.SeverityError, "twitter:card", "Twitter card duplicada (%d encontradas)", len(cards))
+	default:
+		switch cards[0] {
+		case "summary", "summary_large_image", "app", "player":
+		default:
+			add("seo-twitter", lint.SeverityError, "twitter:card", "Tipo de Twitter card desconocido %q", cards[0])
+		}
+	}
+
+	// Alternativas hreflang
+	langs := make(map[string]int)
+	for _, alt := range meta.Alternates {
+		element := fmt.Sprintf(`link[hreflang=%q]`, alt.Lang)
+		if !hreflangPattern.MatchString(alt.Lang) {
+			add("seo-hreflang", lint.SeverityError, element, "Código hreflang no válido")
+		}
+		if !isAbsolute(alt.Href) {
+			add("seo-hreflang", lint.SeverityError, element, "La URL alternativa no es absoluta: %q", alt.Href)
+		}
+		langs[strings.ToLower(alt.Lang)]++
+		if langs[strings.ToLower(alt.Lang)] == 2 {
+			add("seo-hreflang", lint.SeverityError, element, "hreflang duplicado")
+		}
+	}
+
+	// Datos estructurados
+	for i, data := range meta.StructuredData {
+		element := fmt.Sprintf("script[type=\"application/ld+json\"]:nth(%d)", i+1)
+		if data.Err != nil {
+			add("seo-jsonld", lint.SeverityError, element, "JSON-LD no válido: %v", data.Err)
+			continue
+		}
+		if len(data.Types) == 0 {
+			add("seo-jsonld", lint.SeverityWarning, element, "El bloque JSON-LD no tiene @type")
+		}
+		if !hasContext(data.Data) {
+			add("seo-jsonld", lint.SeverityWarning, element, "El bloque JSON-LD no tiene @context")
+		}
+	}
+
+	return issues
+}
+
+// Check extrae y valida los metadatos del documento
+func Check(doc *goquery.Document) (*Metadata, []lint.Issue) {
+	meta := Extract(doc)
+	return meta, Validate(meta)
+}
+
+// structuredTypes recoge los @type del bloque JSON-LD, incluidos los de @graph
+func structuredTypes(data any) []string {
+	var types []string
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			types = append(types, structuredTypes(item)...)
+		}
+	case map[string]any:
+		switch t := v["@type"].(type) {
+		case string:
+			types = append(types, t)
+		case []any:
+			for _, item := range t {
+				if s, ok := item.(string); ok {
+					types = append(types, s)
+				}
+			}
+		}
+		if graph, ok := v["@graph"]; ok {
+			types = append(types, structuredTypes(graph)...)
+		}
+	}
+	return types
+}
+
+func hasContext(data any) bool {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			if !hasContext(item) {
+				return false
+			}
+		}
+		return len(v) > 0
+	case map[string]any:
+		_, ok := v["@context"]
+		return ok
+	}
+	return false
+}
+
+func isAbsolute(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func length(s string) int {
+	return len([]rune(s))
+}
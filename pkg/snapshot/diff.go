@@ -0,0 +1,151 @@
+// pkg/snapshot/diff.go
+
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SectionChange describe una sección cuyo contenido ha cambiado
+type SectionChange struct {
+	ID     string
+	Before Section
+	After  Section
+}
+
+// Diff contiene las diferencias estructurales entre dos instantáneas
+type Diff struct {
+	TitleBefore     string
+	TitleAfter      string
+	AddedSections   []Section
+	RemovedSections []Section
+	ChangedSections []SectionChange
+	AddedLinks      []string
+	RemovedLinks    []string
+}
+
+// Empty indica si no hay diferencias
+func (d *Diff) Empty() bool {
+	return d.TitleBefore == d.TitleAfter &&
+		len(d.AddedSections) == 0 && len(d.RemovedSections) == 0 && len(d.ChangedSections) == 0 &&
+		len(d.AddedLinks) == 0 && len(d.RemovedLinks) == 0
+}
+
+// Compare calcula las diferencias entre la instantánea esperada y la actual
+func Compare(expected, actual *Page) *Diff {
+	diff := &Diff{TitleBefore: expected.Title, TitleAfter: actual.Title}
+
+	before := make(map[string]Section, len(expected.Sections))
+	for _, section := range expected.Sections {
+		before[section.ID] = section
+	}
+	after := make(map[string]Section, len(actual.Sections))
+	for _, section := range actual.Sections {
+		after[section.ID] = section
+		old, exists := before[section.ID]
+		switch {
+		case !exists:
+			diff.AddedSections = append(diff.AddedSections, section)
+		case old != section:
+			diff.ChangedSections = append(diff.ChangedSections, SectionChange{ID: section.ID, Before: old, After: section})
+		}
+	}
+	for _, section := range expected.Sections {
+		if _, exists := after[section.ID]; !exists {
+			diff.RemovedSections = append(diff.RemovedSections, section)
+		}
+	}
+
+	diff.AddedLinks, diff.RemovedLinks = setDiff(expected.Links, actual.Links)
+	return diff
+}
+
+// setDiff devuelve los elementos añadidos y eliminados entre dos listas
+func setDiff(before, after []string) (added, removed []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, v := range before {
+		inBefore[v] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, v := range after {
+		inAfter[v] = true
+		if !inBefore[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range before {
+		if !inAfter[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// String muestra las diferencias de forma legible
+func (d *Diff) String() string {
+	var b strings.Builder
+	if d.TitleBefore != d.TitleAfter {
+		fmt.Fprintf(&b, "~ title: %q -> %q\n", d.TitleBefore, d.TitleAfter)
+	}
+	for _, s := range d.AddedSections {
+		fmt.Fprintf(&b, "+ section %s: %q\n", s.ID, excerpt(s.Heading, s.Text))
+	}
+	for _, s := range d.RemovedSections {
+		fmt.Fprintf(&b, "- section %s: %q\n", s.ID, excerpt(s.Heading, s.Text))
+	}
+	for _, c := range d.ChangedSections {
+		if c.Before.Heading != c.After.Heading {
+			fmt.Fprintf(&b, "~ section %s heading: %q -> %q\n", c.ID, c.Before.Heading, c.After.Heading)
+		}
+		if c.Before.Text != c.After.Text {
+			before, after := changedWindow(c.Before.Text, c.After.Text)
+			fmt.Fprintf(&b, "~ section %s text: %q -> %q\n", c.ID, before, after)
+		}
+	}
+	for _, link := range d.AddedLinks {
+		fmt.Fprintf(&b, "+ link %s\n", link)
+	}
+	for _, link := range d.RemovedLinks {
+		fmt.Fprintf(&b, "- link %s\n", link)
+	}
+	return b.String()
+}
+
+const excerptLength = 60
+
+func excerpt(heading, text string) string {
+	if heading != "" {
+		return heading
+	}
+	return truncate(text)
+}
+
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= excerptLength {
+		return s
+	}
+	return string(runes[:excerptLength]) + "…"
+}
+
+// changedWindow recorta ambos textos alrededor del primer punto en que difieren
+func changedWindow(before, after string) (string, string) {
+	b, a := []rune(before), []rune(after)
+	i := 0
+	for i < len(b) && i < len(a) && b[i] == a[i] {
+		i++
+	}
+	start := max(0, i-excerptLength/3)
+	cut := func(r []rune) string {
+		if start >= len(r) {
+			return ""
+		}
+		s := truncate(string(r[start:]))
+		if start > 0 {
+			s = "…" + s
+		}
+		return s
+	}
+	return cut(b), cut(a)
+}
@@ -0,0 +1,79 @@
+// pkg/snapshot/snapshot.go
+
+// Package snapshot serializa la estructura normalizada de una página (título,
+// secciones y enlaces) a ficheros golden y muestra las diferencias estructurales.
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Section representa una sección de la página
+type Section struct {
+	ID      string `json:"id"`
+	Heading string `json:"heading,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Page es la instantánea normalizada de la estructura de una página
+type Page struct {
+	Title    string    `json:"title"`
+	Sections []Section `json:"sections"`
+	Links    []string  `json:"links"`
+}
+
+// Capture genera la instantánea del documento; sectionQuery selecciona las secciones
+func Capture(doc *goquery.Document, sectionQuery string, links []string) *Page {
+	page := &Page{
+		Title:    normalize(doc.Find("title").First().Text()),
+		Sections: []Section{},
+	}
+
+	doc.Find(sectionQuery).Each(func(i int, s *goquery.Selection) {
+		id, _ := s.Attr("id")
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+		}
+		page.Sections = append(page.Sections, Section{
+			ID:      id,
+			Heading: normalize(s.Find("h1, h2, h3, h4, h5, h6").First().Text()),
+			Text:    textContent(s),
+		})
+	})
+
+	page.Links = append([]string{}, links...)
+	sort.Strings(page.Links)
+	return page
+}
+
+// textContent concatena los nodos de texto separándolos con espacios, para que
+// "<h2>Cursos</h2><p>Aprende</p>" no se convierta en "CursosAprende"
+func textContent(s *goquery.Selection) string {
+	var parts []string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			parts = append(parts, n.Data)
+		case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range s.Nodes {
+		walk(n)
+	}
+	return normalize(strings.Join(parts, " "))
+}
+
+// normalize colapsa los espacios en blanco para que el formato del HTML no genere diferencias
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
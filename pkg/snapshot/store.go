@@ -0,0 +1,117 @@
+// pkg/snapshot/store.go
+
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ActualSuffix es el sufijo de la instantánea actual que se guarda cuando no coincide
+const ActualSuffix = ".actual.json"
+
+// MismatchError indica que la instantánea no coincide con el fichero golden
+type MismatchError struct {
+	Name string
+	Diff *Diff
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("snapshot mismatch for %s:\n%s", e.Name, e.Diff)
+}
+
+// MissingGoldenError indica que no existe el fichero golden. La instantánea actual queda
+// guardada junto a donde iría el golden para poder aprobarla.
+type MissingGoldenError struct {
+	Name   string
+	Actual string
+}
+
+func (e *MissingGoldenError) Error() string {
+	return fmt.Sprintf("no golden snapshot for %s: approve %s or run with UPDATE_BASELINES=1", e.Name, e.Actual)
+}
+
+// Store gestiona los ficheros golden de las instantáneas
+type Store struct {
+	Dir    string
+	Update bool
+}
+
+// NewStore crea un Store; si la variable de entorno UPDATE_BASELINES está definida se actualizan los golden
+func NewStore(dir string) *Store {
+	return &Store{
+		Dir:    dir,
+		Update: os.Getenv("UPDATE_BASELINES") != "",
+	}
+}
+
+// GoldenPath devuelve la ruta del fichero golden
+func (s *Store) GoldenPath(name string) string {
+	return filepath.Join(s.Dir, name+".json")
+}
+
+// Load lee una instantánea del fichero golden
+func (s *Store) Load(name string) (*Page, error) {
+	return LoadFile(s.GoldenPath(name))
+}
+
+// Assert compara la instantánea con el fichero golden. En modo actualización la
+// instantánea pasa a ser el nuevo golden; si no lo está y el golden no existe, se
+// devuelve un MissingGoldenError para que un golden olvidado no haga pasar la prueba.
+func (s *Store) Assert(name string, actual *Page) (*Diff, error) {
+	actualPath := filepath.Join(s.Dir, name+ActualSuffix)
+	if s.Update {
+		os.Remove(actualPath)
+		return &Diff{}, writePage(s.GoldenPath(name), actual)
+	}
+
+	expected, err := s.Load(name)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := writePage(actualPath, actual); err != nil {
+			return nil, err
+		}
+		return nil, &MissingGoldenError{Name: name, Actual: actualPath}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := Compare(expected, actual)
+	if diff.Empty() {
+		os.Remove(actualPath)
+		return diff, nil
+	}
+
+	// Se guarda la instantánea actual junto al golden para poder aprobarla después
+	if err := writePage(actualPath, actual); err != nil {
+		return diff, err
+	}
+	return diff, &MismatchError{Name: name, Diff: diff}
+}
+
+// LoadFile lee una instantánea de un fichero JSON
+func LoadFile(path string) (*Page, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var page Page
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	return &page, nil
+}
+
+func writePage(path string, page *Page) error {
+	data, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,191 @@
+// pkg/storagestate/drivers.go
+
+package storagestate
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/storage"
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+)
+
+// CaptureChromedp guarda en state las cookies del navegador y el almacenamiento del documento actual
+func CaptureChromedp(state *State) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		cookies, err := storage.GetCookies().Do(ctx)
+		if err != nil {
+			return err
+		}
+		state.Cookies = state.Cookies[:0]
+		for _, cookie := range cookies {
+			expires := cookie.Expires
+			if cookie.Session {
+				expires = -1
+			}
+			state.Cookies = append(state.Cookies, Cookie{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   cookie.Domain,
+				Path:     cookie.Path,
+				Expires:  expires,
+				HTTPOnly: cookie.HTTPOnly,
+				Secure:   cookie.Secure,
+				SameSite: string(cookie.SameSite),
+			})
+		}
+
+		var origin Origin
+		if err := chromedp.Evaluate(captureScript, &origin).Do(ctx); err != nil {
+			return err
+		}
+		state.addOrigin(origin)
+		return nil
+	})
+}
+
+// Chromedp restaura el estado en la pestaña; debe ejecutarse antes de navegar
+func (s *State) Chromedp() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var params []*network.CookieParam
+		for _, cookie := range s.valid(time.Now()) {
+			param := &network.CookieParam{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   cookie.Domain,
+				Path:     cookie.Path,
+				HTTPOnly: cookie.HTTPOnly,
+				Secure:   cookie.Secure,
+				SameSite: network.CookieSameSite(cookie.SameSite),
+			}
+			if cookie.Expires > 0 {
+				expires := cdp.TimeSinceEpoch(time.Unix(0, int64(cookie.Expires*float64(time.Second))))
+				param.Expires = &expires
+			}
+			params = append(params, param)
+		}
+		if len(params) > 0 {
+			if err := network.SetCookies(params).Do(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, local := range []bool{true, false} {
+			script, err := restoreScript(s.Origins, local)
+			if err != nil {
+				return err
+			}
+			if script == "" {
+				continue
+			}
+			if _, err := page.AddScriptToEvaluateOnNewDocument(script).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CapturePlaywright guarda las cookies y el localStorage del contexto y el sessionStorage
+// de sus páginas abiertas
+func CapturePlaywright(browserContext playwright.BrowserContext) (*State, error) {
+	captured, err := browserContext.StorageState()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	for _, cookie := range captured.Cookies {
+		sameSite := ""
+		if cookie.SameSite != nil {
+			sameSite = string(*cookie.SameSite)
+		}
+		state.Cookies = append(state.Cookies, Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   cookie.Domain,
+			Path:     cookie.Path,
+			Expires:  cookie.Expires,
+			HTTPOnly: cookie.HttpOnly,
+			Secure:   cookie.Secure,
+			SameSite: sameSite,
+		})
+	}
+	for _, origin := range captured.Origins {
+		entries := make([]Entry, 0, len(origin.LocalStorage))
+		for _, entry := range origin.LocalStorage {
+			entries = append(entries, Entry{Name: entry.Name, Value: entry.Value})
+		}
+		state.addOrigin(Origin{Origin: origin.Origin, LocalStorage: entries})
+	}
+
+	// Playwright no incluye el sessionStorage: se lee de cada página abierta
+	for _, p := range browserContext.Pages() {
+		value, err := p.Evaluate(captureScript)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var current Origin
+		if err := json.Unmarshal(data, &current); err != nil {
+			return nil, err
+		}
+		if existing, ok := state.Origin(current.Origin); ok {
+			current.LocalStorage = existing.LocalStorage
+		}
+		state.addOrigin(current)
+	}
+	return state, nil
+}
+
+// PlaywrightOptions devuelve el estado en el formato de BrowserNewContextOptions.StorageState
+// (cookies vigentes y localStorage)
+func (s *State) PlaywrightOptions() *playwright.OptionalStorageState {
+	options := &playwright.OptionalStorageState{}
+	for _, cookie := range s.valid(time.Now()) {
+		optional := playwright.OptionalCookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   playwright.String(cookie.Domain),
+			Path:     playwright.String(cookie.Path),
+			Expires:  playwright.Float(cookie.Expires),
+			HttpOnly: playwright.Bool(cookie.HTTPOnly),
+			Secure:   playwright.Bool(cookie.Secure),
+		}
+		switch cookie.SameSite {
+		case "Strict":
+			optional.SameSite = playwright.SameSiteAttributeStrict
+		case "Lax":
+			optional.SameSite = playwright.SameSiteAttributeLax
+		case "None":
+			optional.SameSite = playwright.SameSiteAttributeNone
+		}
+		options.Cookies = append(options.Cookies, optional)
+	}
+	for _, origin := range s.Origins {
+		entries := make([]playwright.NameValue, 0, len(origin.LocalStorage))
+		for _, entry := range origin.LocalStorage {
+			entries = append(entries, playwright.NameValue{Name: entry.Name, Value: entry.Value})
+		}
+		options.Origins = append(options.Origins, playwright.Origin{Origin: origin.Origin, LocalStorage: entries})
+	}
+	return options
+}
+
+// ApplyPlaywright restaura el sessionStorage, que Playwright no admite en las opciones del contexto,
+// mediante un script que se ejecuta al cargar cada documento
+func (s *State) ApplyPlaywright(browserContext playwright.BrowserContext) error {
+	script, err := restoreScript(s.Origins, false)
+	if err != nil || script == "" {
+		return err
+	}
+	return browserContext.AddInitScript(playwright.Script{Content: playwright.String(script)})
+}
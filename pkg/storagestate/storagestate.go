@@ -0,0 +1,221 @@
+// pkg/storagestate/storagestate.go
+
+// Package storagestate guarda en un fichero JSON las cookies, el localStorage y el
+// sessionStorage de una sesión de chromedp o Playwright y los restaura en sesiones
+// nuevas. Así una suite puede aceptar las cookies una vez y saltarse el banner en el
+// resto de pruebas, o ejecutar la misma prueba con estados precargados distintos
+// (cliente habitual, primera visita...). El formato es compatible con el storageState
+// de Playwright, ampliado con el sessionStorage de cada origen.
+package storagestate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cookie es una cookie del navegador. Expires son los segundos desde epoch; -1 es una cookie de sesión.
+type Cookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires"`
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	// SameSite es "Strict", "Lax", "None" o vacío si no se indicó
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// Expired indica si la cookie ya ha caducado en el instante indicado
+func (c Cookie) Expired(now time.Time) bool {
+	return c.Expires > 0 && c.Expires < float64(now.Unix())
+}
+
+// Entry es una clave del localStorage o del sessionStorage
+type Entry struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Origin es el almacenamiento de un origen (esquema, host y puerto)
+type Origin struct {
+	Origin         string  `json:"origin"`
+	LocalStorage   []Entry `json:"localStorage"`
+	SessionStorage []Entry `json:"sessionStorage,omitempty"`
+}
+
+// State es el estado de almacenamiento de una sesión del navegador
+type State struct {
+	// Name identifica el estado en los logs (por ejemplo "returning-customer")
+	Name    string   `json:"name,omitempty"`
+	Cookies []Cookie `json:"cookies"`
+	Origins []Origin `json:"origins"`
+}
+
+// Load lee un estado guardado con Save
+func Load(file string) (*State, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("estado de almacenamiento inválido en %s: %w", file, err)
+	}
+	if state.Name == "" {
+		state.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	return &state, nil
+}
+
+// FromEnv carga el estado del fichero indicado en STORAGE_STATE; devuelve nil si no está definida
+func FromEnv() (*State, error) {
+	file := strings.TrimSpace(os.Getenv("STORAGE_STATE"))
+	if file == "" {
+		return nil, nil
+	}
+	return Load(file)
+}
+
+// Save escribe el estado en un fichero JSON, creando el directorio si no existe
+func (s *State) Save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0o644)
+}
+
+// IsEmpty indica si el estado no contiene cookies ni almacenamiento
+func (s *State) IsEmpty() bool {
+	if s == nil {
+		return true
+	}
+	for _, origin := range s.Origins {
+		if len(origin.LocalStorage) > 0 || len(origin.SessionStorage) > 0 {
+			return false
+		}
+	}
+	return len(s.Cookies) == 0
+}
+
+// Cookie devuelve la cookie con el nombre indicado
+func (s *State) Cookie(name string) (Cookie, bool) {
+	for _, cookie := range s.Cookies {
+		if cookie.Name == name {
+			return cookie, true
+		}
+	}
+	return Cookie{}, false
+}
+
+// Origin devuelve el almacenamiento del origen indicado
+func (s *State) Origin(origin string) (Origin, bool) {
+	origin = strings.TrimSuffix(origin, "/")
+	for _, o := range s.Origins {
+		if o.Origin == origin {
+			return o, true
+		}
+	}
+	return Origin{}, false
+}
+
+// valid devuelve las cookies que no han caducado en el instante indicado
+func (s *State) valid(now time.Time) []Cookie {
+	var cookies []Cookie
+	for _, cookie := range s.Cookies {
+		if !cookie.Expired(now) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	return cookies
+}
+
+// seededKey es la clave que marca un almacenamiento como ya restaurado. Evita que el script
+// vuelva a escribir en cada documento las claves que la página haya borrado después, igual
+// que Playwright, que solo siembra el localStorage al crear el contexto.
+const seededKey = "__storagestate_seeded"
+
+// restoreScript genera el script que, al cargar cada documento, rellena el almacenamiento
+// de su origen una sola vez. Solo se escriben las claves que no existen para no pisar los
+// cambios que la propia página haya hecho antes.
+func restoreScript(origins []Origin, local bool) (string, error) {
+	areas := make(map[string]map[string]string)
+	for _, origin := range origins {
+		entries := origin.SessionStorage
+		if local {
+			entries = origin.LocalStorage
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		values := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			values[entry.Name] = entry.Value
+		}
+		areas[strings.TrimSuffix(origin.Origin, "/")] = values
+	}
+	if len(areas) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(areas)
+	if err != nil {
+		return "", err
+	}
+	area := "sessionStorage"
+	if local {
+		area = "localStorage"
+	}
+	return fmt.Sprintf(`(() => {
+	const entries = %s[location.origin];
+	if (!entries) return;
+	try {
+		const storage = window.%s;
+		if (storage.getItem(%q) !== null) return;
+		for (const [name, value] of Object.entries(entries)) {
+			if (storage.getItem(name) === null) storage.setItem(name, value);
+		}
+		storage.setItem(%q, "1");
+	} catch (e) {}
+})();`, data, area, seededKey, seededKey), nil
+}
+
+// captureScript lee el origen y el contenido de los dos almacenamientos del documento actual
+const captureScript = `(() => {
+	const dump = storage => {
+		const entries = [];
+		for (let i = 0; i < storage.length; i++) {
+			const name = storage.key(i);
+			if (name === "` + seededKey + `") continue;
+			entries.push({name, value: storage.getItem(name)});
+		}
+		return entries;
+	};
+	try {
+		return {origin: location.origin, localStorage: dump(window.localStorage), sessionStorage: dump(window.sessionStorage)};
+	} catch (e) {
+		return {origin: location.origin, localStorage: [], sessionStorage: []};
+	}
+})()`
+
+// addOrigin incorpora el almacenamiento de un documento al estado, sustituyendo el que hubiera del mismo origen
+func (s *State) addOrigin(origin Origin) {
+	if origin.Origin == "" || origin.Origin == "null" {
+		return
+	}
+	for i := range s.Origins {
+		if s.Origins[i].Origin == origin.Origin {
+			s.Origins[i] = origin
+			return
+		}
+	}
+	s.Origins = append(s.Origins, origin)
+}
@@ -0,0 +1,257 @@
+// pkg/vcr/cassette.go
+
+// Package vcr graba en ficheros (cassettes) las respuestas reales de las páginas
+// estáticas y las reproduce después de forma determinista, sin red. Se usa como
+// http.RoundTripper de los clientes de HomePage, SandboxPage y links.Checker: los
+// tests corren en CI sin acceso a internet y se vuelven a grabar de vez en cuando
+// para detectar los cambios reales del sitio.
+package vcr
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Mode indica si el grabador reproduce, graba, ambas cosas o ninguna
+type Mode string
+
+const (
+	// ModeLive deja pasar todas las peticiones a la red sin leer ni escribir la cassette
+	ModeLive Mode = "live"
+	// ModeReplay solo reproduce la cassette; las peticiones no grabadas salen a la red
+	// (o fallan en modo estricto) y no se guardan
+	ModeReplay Mode = "replay"
+	// ModeRecord ignora la cassette existente y graba de nuevo todas las respuestas
+	ModeRecord Mode = "record"
+	// ModeAuto reproduce lo grabado y graba las peticiones que falten
+	ModeAuto Mode = "auto"
+)
+
+// ModeFromEnv devuelve el modo indicado en VCR_MODE y si VCR_STRICT pide el modo estricto.
+// Si VCR_MODE no está definida se usa ModeLive: grabar o reproducir tiene que pedirse
+// expresamente para que una ejecución normal no escriba cassettes sin querer.
+func ModeFromEnv() (Mode, bool, error) {
+	strict := os.Getenv("VCR_STRICT") == "1" || strings.EqualFold(os.Getenv("VCR_STRICT"), "true")
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(os.Getenv("VCR_MODE")))); mode {
+	case "":
+		return ModeLive, strict, nil
+	case ModeLive, ModeReplay, ModeRecord, ModeAuto:
+		return mode, strict, nil
+	default:
+		return "", false, fmt.Errorf("unknown VCR mode %q", mode)
+	}
+}
+
+// sensitiveHeaders son las cabeceras de la petición que no se guardan en la cassette
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// redactedValue sustituye el valor de las cookies de la respuesta en la cassette
+const redactedValue = "REDACTED"
+
+// Request es la petición grabada
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response es la respuesta grabada
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+	TLS        *TLS        `json:"tls,omitempty"`
+}
+
+// TLS conserva lo necesario del estado TLS para la auditoría de seguridad
+type TLS struct {
+	Version     uint16 `json:"version"`
+	CipherSuite uint16 `json:"cipher_suite"`
+	ServerName  string `json:"server_name,omitempty"`
+	// Certificates son los certificados del servidor en DER
+	Certificates [][]byte `json:"certificates,omitempty"`
+}
+
+// Interaction es una petición y su respuesta
+type Interaction struct {
+	Request    Request   `json:"request"`
+	Response   Response  `json:"response"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Cassette es el conjunto de interacciones grabadas en un fichero
+type Cassette struct {
+	Path         string        `json:"-"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load lee una cassette; si el fichero no existe devuelve una cassette vacía
+func Load(path string) (*Cassette, error) {
+	cassette := &Cassette{Path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cassette, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("cassette inválida %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Save escribe la cassette en su fichero, creando el directorio si no existe
+func (c *Cassette) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, append(data, '\n'), 0o644)
+}
+
+// Body es un cuerpo grabado: texto si es UTF-8 válido y base64 en otro caso
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// readBody lee el cuerpo y lo deja de nuevo disponible para el siguiente lector
+func readBody(body *io.ReadCloser) (Body, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// newRequest graba una petición sin las cabeceras sensibles
+func newRequest(req *http.Request) (Request, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return Request{}, err
+	}
+	header := req.Header.Clone()
+	for _, name := range sensitiveHeaders {
+		header.Del(name)
+	}
+	return Request{Method: req.Method, URL: req.URL.String(), Header: header, Body: body}, nil
+}
+
+// newResponse graba una respuesta sin los valores de sus cookies
+func newResponse(resp *http.Response) (Response, error) {
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	header := resp.Header.Clone()
+	if cookies := header.Values("Set-Cookie"); len(cookies) > 0 {
+		header.Del("Set-Cookie")
+		for _, cookie := range cookies {
+			header.Add("Set-Cookie", redactCookie(cookie))
+		}
+	}
+	recorded := Response{StatusCode: resp.StatusCode, Header: header, Body: body}
+	if state := resp.TLS; state != nil {
+		recorded.TLS = &TLS{Version: state.Version, CipherSuite: state.CipherSuite, ServerName: state.ServerName}
+		for _, cert := range state.PeerCertificates {
+			recorded.TLS.Certificates = append(recorded.TLS.Certificates, cert.Raw)
+		}
+	}
+	return recorded, nil
+}
+
+// redactCookie oculta el valor de una cabecera Set-Cookie y conserva su nombre y sus
+// atributos, que la auditoría de seguridad necesita al reproducir la respuesta
+func redactCookie(line string) string {
+	pair, attributes, _ := strings.Cut(line, ";")
+	name, _, found := strings.Cut(pair, "=")
+	if !found {
+		return line
+	}
+	redacted := strings.TrimSpace(name) + "=" + redactedValue
+	if attributes != "" {
+		redacted += ";" + attributes
+	}
+	return redacted
+}
+
+// toHTTP construye la respuesta reproducida para la petición indicada
+func (r Response) toHTTP(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	if r.TLS != nil {
+		state := &tls.ConnectionState{
+			Version:           r.TLS.Version,
+			CipherSuite:       r.TLS.CipherSuite,
+			ServerName:        r.TLS.ServerName,
+			HandshakeComplete: true,
+		}
+		for _, raw := range r.TLS.Certificates {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return nil, fmt.Errorf("certificado grabado inválido: %w", err)
+			}
+			state.PeerCertificates = append(state.PeerCertificates, cert)
+		}
+		resp.TLS = state
+	}
+	return resp, nil
+}
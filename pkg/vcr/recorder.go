@@ -0,0 +1,260 @@
+// pkg/vcr/recorder.go
+
+package vcr
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Matcher decide si una petición corresponde a una interacción grabada
+type Matcher func(req *http.Request, body []byte, recorded Request) bool
+
+// MatchMethod compara el método HTTP
+func MatchMethod(req *http.Request, _ []byte, recorded Request) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchURL compara la URL completa, con los parámetros de la query en cualquier orden
+func MatchURL(req *http.Request, _ []byte, recorded Request) bool {
+	return sameURL(req.URL.String(), recorded.URL)
+}
+
+// MatchBody compara el cuerpo de la petición
+func MatchBody(_ *http.Request, body []byte, recorded Request) bool {
+	return bytes.Equal(body, recorded.Body)
+}
+
+// DefaultMatcher compara el método y la URL
+var DefaultMatcher = All(MatchMethod, MatchURL)
+
+// All combina varios criterios: la interacción debe cumplirlos todos
+func All(matchers ...Matcher) Matcher {
+	return func(req *http.Request, body []byte, recorded Request) bool {
+		for _, match := range matchers {
+			if !match(req, body, recorded) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchHeaders compara los valores de las cabeceras indicadas
+func MatchHeaders(names ...string) Matcher {
+	return func(req *http.Request, _ []byte, recorded Request) bool {
+		for _, name := range names {
+			if req.Header.Get(name) != recorded.Header.Get(name) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// IgnoreQuery compara la URL sin tener en cuenta los parámetros indicados (marcas de tiempo, cache busters...)
+func IgnoreQuery(params ...string) Matcher {
+	return func(req *http.Request, _ []byte, recorded Request) bool {
+		return sameURL(req.URL.String(), recorded.URL, params...)
+	}
+}
+
+// sameURL compara dos URLs normalizando el orden de la query y descartando los parámetros ignorados
+func sameURL(a, b string, ignored ...string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	qa, qb := ua.Query(), ub.Query()
+	for _, param := range ignored {
+		qa.Del(param)
+		qb.Del(param)
+	}
+	ua.RawQuery, ub.RawQuery = qa.Encode(), qb.Encode()
+	ua.Fragment, ub.Fragment = "", ""
+	return ua.String() == ub.String()
+}
+
+// UnrecordedError indica que, en modo estricto, se ha hecho una petición que no está en la cassette
+type UnrecordedError struct {
+	Method string
+	URL    string
+	Path   string
+}
+
+func (e *UnrecordedError) Error() string {
+	return fmt.Sprintf("vcr: %s %s not recorded in %s", e.Method, e.URL, e.Path)
+}
+
+// Recorder es un http.RoundTripper que reproduce y graba las interacciones de una cassette
+type Recorder struct {
+	Mode Mode
+	// Strict hace fallar las peticiones no grabadas en lugar de dejarlas salir a la red
+	Strict  bool
+	Matcher Matcher
+	// Transport es el transporte real que se usa al grabar y en ModeLive; por defecto http.DefaultTransport
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     map[int]bool
+	changed  bool
+}
+
+// New abre la cassette del fichero indicado con el modo dado
+func New(path string, mode Mode) (*Recorder, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if mode == ModeRecord {
+		cassette.Interactions = nil
+	}
+	return &Recorder{
+		Mode:     mode,
+		Matcher:  DefaultMatcher,
+		cassette: cassette,
+		used:     make(map[int]bool),
+	}, nil
+}
+
+// FromEnv abre la cassette con el modo de VCR_MODE y el modo estricto de VCR_STRICT
+func FromEnv(path string) (*Recorder, error) {
+	mode, strict, err := ModeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	recorder, err := New(path, mode)
+	if err != nil {
+		return nil, err
+	}
+	recorder.Strict = strict
+	return recorder, nil
+}
+
+// Client devuelve un cliente HTTP que usa el grabador como transporte
+func (r *Recorder) Client(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: r, Timeout: timeout}
+}
+
+// Cassette devuelve la cassette del grabador
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// RoundTrip reproduce la interacción grabada que corresponde a la petición o, según el modo, la graba
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if r.Mode == ModeLive {
+		return transport.RoundTrip(req)
+	}
+
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Mode != ModeRecord {
+		if interaction, ok := r.find(req, recorded.Body); ok {
+			return interaction.Response.toHTTP(req)
+		}
+		if r.Strict {
+			return nil, &UnrecordedError{Method: req.Method, URL: req.URL.String(), Path: r.cassette.Path}
+		}
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil || r.Mode == ModeReplay {
+		return resp, err
+	}
+
+	response, err := newResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:    recorded,
+		Response:   response,
+		RecordedAt: time.Now().UTC(),
+	})
+	r.used[len(r.cassette.Interactions)-1] = true
+	r.changed = true
+	return resp, nil
+}
+
+// find busca la primera interacción no usada que corresponda a la petición; si todas se
+// han usado ya, repite la primera, de modo que una página puede descargarse varias veces
+func (r *Recorder) find(req *http.Request, body []byte) (Interaction, bool) {
+	matcher := r.Matcher
+	if matcher == nil {
+		matcher = DefaultMatcher
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	first := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !matcher(req, body, interaction.Request) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction, true
+		}
+		if first < 0 {
+			first = i
+		}
+	}
+	if first < 0 {
+		return Interaction{}, false
+	}
+	return r.cassette.Interactions[first], true
+}
+
+// Unused devuelve las URLs de las interacciones grabadas que no se han reproducido
+func (r *Recorder) Unused() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var urls []string
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			urls = append(urls, interaction.Request.Method+" "+interaction.Request.URL)
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// Save guarda la cassette si se ha grabado alguna interacción nueva
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.changed {
+		return nil
+	}
+	// El orden de grabación depende de la concurrencia: se guarda ordenado para que los diffs sean estables
+	sorted := &Cassette{Path: r.cassette.Path, Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+	sort.SliceStable(sorted.Interactions, func(i, j int) bool {
+		a, b := sorted.Interactions[i].Request, sorted.Interactions[j].Request
+		if a.URL != b.URL {
+			return a.URL < b.URL
+		}
+		return a.Method < b.Method
+	})
+	if err := sorted.Save(); err != nil {
+		return err
+	}
+	r.changed = false
+	return nil
+}
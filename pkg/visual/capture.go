@@ -0,0 +1,72 @@
+// pkg/visual/capture.go
+
+package visual
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+)
+
+// maskScript superpone un bloque sólido sobre los elementos que coinciden con los selectores
+const maskScript = `(function(selectors) {
+	document.querySelectorAll('[data-visual-mask]').forEach(e => e.remove());
+	selectors.forEach(sel => document.querySelectorAll(sel).forEach(el => {
+		const r = el.getBoundingClientRect();
+		const mask = document.createElement('div');
+		mask.setAttribute('data-visual-mask', sel);
+		mask.style.cssText = 'position:absolute;z-index:2147483647;background:#FF00FF;pointer-events:none;' +
+			'left:' + (r.left + window.scrollX) + 'px;top:' + (r.top + window.scrollY) + 'px;' +
+			'width:' + r.width + 'px;height:' + r.height + 'px;';
+		document.body.appendChild(mask);
+	}));
+	document.documentElement.style.caretColor = 'transparent';
+	document.getAnimations().forEach(a => a.finish());
+	return true;
+})(%s)`
+
+// CaptureChromedp devuelve una acción que enmascara las zonas dinámicas y captura
+// la página completa o, si se indica selector, solo ese elemento, en formato PNG
+func CaptureChromedp(selector string, masks []string, out *[]byte) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		selectors, err := json.Marshal(masks)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if err := chromedp.Evaluate(fmt.Sprintf(maskScript, selectors), &ok).Do(ctx); err != nil {
+			return err
+		}
+		if selector == "" {
+			return chromedp.FullScreenshot(out, 100).Do(ctx)
+		}
+		return chromedp.Screenshot(selector, out, chromedp.ByQuery, chromedp.NodeVisible).Do(ctx)
+	})
+}
+
+// CapturePlaywright captura la página completa o el elemento indicado, enmascarando las zonas dinámicas
+func CapturePlaywright(page playwright.Page, selector string, masks []string) ([]byte, error) {
+	locators := make([]playwright.Locator, 0, len(masks))
+	for _, mask := range masks {
+		locators = append(locators, page.Locator(mask))
+	}
+
+	if selector == "" {
+		return page.Screenshot(playwright.PageScreenshotOptions{
+			FullPage:   playwright.Bool(true),
+			Mask:       locators,
+			MaskColor:  playwright.String("#FF00FF"),
+			Animations: playwright.ScreenshotAnimationsDisabled,
+			Caret:      playwright.ScreenshotCaretHide,
+		})
+	}
+	return page.Locator(selector).Screenshot(playwright.LocatorScreenshotOptions{
+		Mask:       locators,
+		MaskColor:  playwright.String("#FF00FF"),
+		Animations: playwright.ScreenshotAnimationsDisabled,
+		Caret:      playwright.ScreenshotCaretHide,
+	})
+}
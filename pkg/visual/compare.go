@@ -0,0 +1,204 @@
+// pkg/visual/compare.go
+
+// Package visual captura capturas de pantalla con chromedp o Playwright y las
+// compara con capturas de referencia almacenadas para detectar regresiones visuales.
+package visual
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// maxYIQDelta es la distancia máxima posible entre dos colores en el espacio YIQ
+const maxYIQDelta = 35215.0
+
+// Region es una zona rectangular de la imagen, en píxeles
+type Region struct {
+	X, Y, Width, Height int
+}
+
+func (r Region) rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height)
+}
+
+// Options configura la comparación de imágenes
+type Options struct {
+	// Threshold es la diferencia perceptual (0..1) a partir de la cual un píxel se considera distinto
+	Threshold float64
+	// MaxDiffRatio es la proporción máxima de píxeles distintos que se tolera
+	MaxDiffRatio float64
+	// MaxDiffPixels es el número máximo de píxeles distintos que se tolera
+	MaxDiffPixels int
+	// Ignore contiene las zonas que no se comparan
+	Ignore []Region
+}
+
+// DefaultOptions devuelve unas opciones de comparación razonables
+func DefaultOptions() Options {
+	return Options{
+		Threshold:    0.1,
+		MaxDiffRatio: 0.001,
+	}
+}
+
+// Result contiene el resultado de comparar dos imágenes
+type Result struct {
+	DiffPixels   int
+	TotalPixels  int
+	SizeMismatch bool
+	Diff         *image.RGBA
+	Match        bool
+}
+
+// Ratio devuelve la proporción de píxeles distintos
+func (r *Result) Ratio() float64 {
+	if r.TotalPixels == 0 {
+		return 0
+	}
+	return float64(r.DiffPixels) / float64(r.TotalPixels)
+}
+
+func (r *Result) String() string {
+	if r.SizeMismatch {
+		return "image size mismatch"
+	}
+	return fmt.Sprintf("%d of %d pixels differ (%.4f%%)", r.DiffPixels, r.TotalPixels, r.Ratio()*100)
+}
+
+var (
+	diffColor    = color.RGBA{R: 255, A: 255}
+	ignoredColor = color.RGBA{R: 255, B: 255, A: 64}
+)
+
+// Compare compara dos imágenes píxel a píxel con una tolerancia perceptual
+func Compare(expected, actual image.Image, opts Options) *Result {
+	eb, ab := expected.Bounds(), actual.Bounds()
+	bounds := image.Rect(0, 0, max(eb.Dx(), ab.Dx()), max(eb.Dy(), ab.Dy()))
+	result := &Result{
+		TotalPixels:  bounds.Dx() * bounds.Dy(),
+		SizeMismatch: eb.Dx() != ab.Dx() || eb.Dy() != ab.Dy(),
+		Diff:         image.NewRGBA(bounds),
+	}
+
+	threshold := opts.Threshold * opts.Threshold * maxYIQDelta
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			p := image.Pt(x, y)
+			if ignored(p, opts.Ignore) {
+				result.Diff.SetRGBA(x, y, ignoredColor)
+				continue
+			}
+			inExpected := p.In(image.Rect(0, 0, eb.Dx(), eb.Dy()))
+			inActual := p.In(image.Rect(0, 0, ab.Dx(), ab.Dy()))
+			if !inExpected || !inActual {
+				result.DiffPixels++
+				result.Diff.SetRGBA(x, y, diffColor)
+				continue
+			}
+
+			e := expected.At(eb.Min.X+x, eb.Min.Y+y)
+			a := actual.At(ab.Min.X+x, ab.Min.Y+y)
+			if yiqDelta(e, a) > threshold {
+				result.DiffPixels++
+				result.Diff.SetRGBA(x, y, diffColor)
+			} else {
+				result.Diff.SetRGBA(x, y, faded(e))
+			}
+		}
+	}
+
+	result.Match = !result.SizeMismatch &&
+		(result.DiffPixels <= opts.MaxDiffPixels || result.Ratio() <= opts.MaxDiffRatio)
+	return result
+}
+
+// CompareBytes decodifica dos PNG y los compara
+func CompareBytes(expected, actual []byte, opts Options) (*Result, error) {
+	e, err := png.Decode(bytes.NewReader(expected))
+	if err != nil {
+		return nil, fmt.Errorf("decoding expected image: %w", err)
+	}
+	a, err := png.Decode(bytes.NewReader(actual))
+	if err != nil {
+		return nil, fmt.Errorf("decoding actual image: %w", err)
+	}
+	return Compare(e, a, opts), nil
+}
+
+// Mask pinta las zonas indicadas de un color sólido para que no varíen entre capturas
+func Mask(img image.Image, regions []Region) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Src)
+	mask := &image.Uniform{C: color.RGBA{R: 255, B: 255, A: 255}}
+	for _, region := range regions {
+		draw.Draw(out, region.rect().Add(img.Bounds().Min).Intersect(out.Bounds()), mask, image.Point{}, draw.Src)
+	}
+	return out
+}
+
+// Triptych compone una imagen con la esperada, la actual y la diferencia en paralelo
+func Triptych(expected, actual image.Image, diff image.Image) *image.RGBA {
+	const gap = 10
+	images := []image.Image{expected, actual, diff}
+	width, height := gap*(len(images)-1), 0
+	for _, img := range images {
+		width += img.Bounds().Dx()
+		height = max(height, img.Bounds().Dy())
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(out, out.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	x := 0
+	for _, img := range images {
+		b := img.Bounds()
+		draw.Draw(out, image.Rect(x, 0, x+b.Dx(), b.Dy()), img, b.Min, draw.Src)
+		x += b.Dx() + gap
+	}
+	return out
+}
+
+func ignored(p image.Point, regions []Region) bool {
+	for _, region := range regions {
+		if p.In(region.rect()) {
+			return true
+		}
+	}
+	return false
+}
+
+// yiqDelta calcula la diferencia perceptual entre dos colores (ver pixelmatch)
+func yiqDelta(c1, c2 color.Color) float64 {
+	r1, g1, b1, a1 := blendWhite(c1)
+	r2, g2, b2, a2 := blendWhite(c2)
+	if r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2 {
+		return 0
+	}
+
+	y := rgb2y(r1, g1, b1) - rgb2y(r2, g2, b2)
+	i := rgb2i(r1, g1, b1) - rgb2i(r2, g2, b2)
+	q := rgb2q(r1, g1, b1) - rgb2q(r2, g2, b2)
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+// blendWhite mezcla el color con un fondo blanco según su transparencia
+func blendWhite(c color.Color) (r, g, b, a float64) {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	a = float64(nc.A) / 255
+	blend := func(v uint8) float64 { return 255 + (float64(v)-255)*a }
+	return blend(nc.R), blend(nc.G), blend(nc.B), a
+}
+
+func rgb2y(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgb2i(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgb2q(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+// faded aclara el píxel original para que la diferencia resalte sobre él
+func faded(c color.Color) color.RGBA {
+	r, g, b, _ := blendWhite(c)
+	y := uint8(255 + (rgb2y(r, g, b)-255)*0.1)
+	return color.RGBA{R: y, G: y, B: y, A: 255}
+}
@@ -0,0 +1,141 @@
+// pkg/visual/store.go
+
+package visual
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// Sufijos de los artefactos que se generan cuando una captura no coincide
+const (
+	ActualSuffix   = ".actual.png"
+	DiffSuffix     = ".diff.png"
+	TriptychSuffix = ".triptych.png"
+)
+
+// MismatchError indica que la captura no coincide con la referencia
+type MismatchError struct {
+	Name     string
+	Result   *Result
+	Triptych string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("visual mismatch for %s: %s (see %s)", e.Name, e.Result, e.Triptych)
+}
+
+// MissingBaselineError indica que no existe la captura de referencia. La captura actual
+// queda guardada junto a donde iría la referencia para poder aprobarla.
+type MissingBaselineError struct {
+	Name   string
+	Actual string
+}
+
+func (e *MissingBaselineError) Error() string {
+	return fmt.Sprintf("no visual baseline for %s: approve %s or run with UPDATE_BASELINES=1", e.Name, e.Actual)
+}
+
+// Store gestiona las capturas de referencia y los artefactos de las diferencias
+type Store struct {
+	BaselineDir string
+	ReportDir   string
+	Update      bool
+}
+
+// NewStore crea un Store; si la variable de entorno UPDATE_BASELINES está definida se actualizan las referencias
+func NewStore(baselineDir, reportDir string) *Store {
+	return &Store{
+		BaselineDir: baselineDir,
+		ReportDir:   reportDir,
+		Update:      os.Getenv("UPDATE_BASELINES") != "",
+	}
+}
+
+// BaselinePath devuelve la ruta de la captura de referencia
+func (s *Store) BaselinePath(name string) string {
+	return filepath.Join(s.BaselineDir, name+".png")
+}
+
+// Assert compara la captura con su referencia. En modo actualización la captura pasa a
+// ser la nueva referencia; si no lo está y la referencia no existe, se devuelve un
+// MissingBaselineError para que una referencia olvidada no haga pasar la prueba.
+func (s *Store) Assert(name string, actual []byte, opts Options) (*Result, error) {
+	actualImg, err := png.Decode(bytes.NewReader(actual))
+	if err != nil {
+		return nil, fmt.Errorf("decoding screenshot %s: %w", name, err)
+	}
+
+	baselinePath := s.BaselinePath(name)
+	if s.Update {
+		if err := writeFile(baselinePath, actual); err != nil {
+			return nil, err
+		}
+		s.cleanArtifacts(name)
+		b := actualImg.Bounds()
+		return &Result{TotalPixels: b.Dx() * b.Dy(), Match: true}, nil
+	}
+
+	expected, err := os.ReadFile(baselinePath)
+	if errors.Is(err, os.ErrNotExist) {
+		actualPath := filepath.Join(s.BaselineDir, name+ActualSuffix)
+		if err := writeFile(actualPath, actual); err != nil {
+			return nil, err
+		}
+		return nil, &MissingBaselineError{Name: name, Actual: actualPath}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expectedImg, err := png.Decode(bytes.NewReader(expected))
+	if err != nil {
+		return nil, fmt.Errorf("decoding baseline %s: %w", baselinePath, err)
+	}
+
+	result := Compare(expectedImg, actualImg, opts)
+	if result.Match {
+		s.cleanArtifacts(name)
+		return result, nil
+	}
+
+	// Se guarda la captura actual junto a la referencia para poder aprobarla después
+	if err := writeFile(filepath.Join(s.BaselineDir, name+ActualSuffix), actual); err != nil {
+		return result, err
+	}
+	if err := writePNG(filepath.Join(s.ReportDir, name+DiffSuffix), result.Diff); err != nil {
+		return result, err
+	}
+	triptych := filepath.Join(s.ReportDir, name+TriptychSuffix)
+	if err := writePNG(triptych, Triptych(expectedImg, actualImg, result.Diff)); err != nil {
+		return result, err
+	}
+
+	return result, &MismatchError{Name: name, Result: result, Triptych: triptych}
+}
+
+func (s *Store) cleanArtifacts(name string) {
+	os.Remove(filepath.Join(s.BaselineDir, name+ActualSuffix))
+	os.Remove(filepath.Join(s.ReportDir, name+DiffSuffix))
+	os.Remove(filepath.Join(s.ReportDir, name+TriptychSuffix))
+}
+
+func writePNG(path string, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
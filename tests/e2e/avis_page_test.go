@@ -2,6 +2,8 @@
 package e2e
 
 import (
+	"math"
+	"slices"
 	"testing"
 	"time"
 	//"fmt"
@@ -20,28 +22,90 @@ const (
 	returnLocation = "Barcelona-El Prat T1 y T2 - ESP"
 )
 
-func verificarBusquedaAvis(page *pages.AvisPage, t *testing.T) {
+// escenarioAvis define las fechas y la oficina de devolución de una búsqueda
+type escenarioAvis struct {
+	nombre       string
+	recogida     time.Time
+	devolucion   time.Time
+	devolucionEn string
+}
+
+// criterio construye la búsqueda del escenario desde la oficina de recogida de los tests
+func (e escenarioAvis) criterio() pages.SearchCriteria {
+	return pages.SearchCriteria{
+		PickupLocation: pickupLocation,
+		ReturnLocation: e.devolucionEn,
+		PickupTime:     e.recogida,
+		ReturnTime:     e.devolucion,
+	}
+}
+
+// proximoDia devuelve la siguiente fecha con el día de la semana indicado, a la hora indicada
+func proximoDia(desde time.Time, dia time.Weekday, hora int) time.Time {
+	dias := (int(dia) - int(desde.Weekday()) + 7) % 7
+	if dias == 0 {
+		dias = 7
+	}
+	fecha := desde.AddDate(0, 0, dias)
+	return time.Date(fecha.Year(), fecha.Month(), fecha.Day(), hora, 0, 0, 0, desde.Location())
+}
+
+func escenariosAvis(desde time.Time) []escenarioAvis {
+	viernes := proximoDia(desde, time.Friday, 10)
+	// Recogida el penúltimo día del mes siguiente y devolución en el mes posterior
+	finDeMes := time.Date(desde.Year(), desde.Month()+2, 0, 12, 0, 0, 0, desde.Location()).AddDate(0, 0, -1)
+	return []escenarioAvis{
+		{nombre: "fin de semana", recogida: viernes, devolucion: viernes.AddDate(0, 0, 3), devolucionEn: returnLocation},
+		{nombre: "cambio de mes", recogida: finDeMes, devolucion: finDeMes.AddDate(0, 0, 3).Add(-2 * time.Hour), devolucionEn: returnLocation},
+		{nombre: "alquiler largo", recogida: viernes.AddDate(0, 0, 7), devolucion: viernes.AddDate(0, 0, 35).Add(4 * time.Hour), devolucionEn: returnLocation},
+		{nombre: "misma oficina", recogida: viernes, devolucion: viernes.AddDate(0, 0, 2)},
+	}
+}
+
+func verificarBusquedaAvis(page *pages.AvisPage, t *testing.T) *pages.AvisResultsPage {
+	return verificarEscenarioAvis(page, t, escenariosAvis(time.Now())[0])
+}
+
+func verificarEscenarioAvis(page *pages.AvisPage, t *testing.T, escenario escenarioAvis) *pages.AvisResultsPage {
+	return verificarEscenarioAvisEn(page, t, urlAvis, escenario)
+}
+
+// verificarEscenarioAvisEn realiza la búsqueda del escenario en la URL indicada, real o réplica local
+func verificarEscenarioAvisEn(page *pages.AvisPage, t *testing.T, url string, escenario escenarioAvis) *pages.AvisResultsPage {
 	startTime := time.Now()
-    logger.Printf("🚀 Iniciando test de AVIS")
-    logger.Printf("📡 Accediendo a la URL: %s",urlAvis )
-	require.NoError(t, page.NavigateTo("https://www.avis.es"))
+    logger.Printf("🚀 Iniciando test de AVIS (%s)%s", escenario.nombre, variante(page.Session()))
+    logger.Printf("📡 Accediendo a la URL: %s", url)
+    logger.Printf("📅 Recogida: %s, devolución: %s", escenario.recogida.Format("2006-01-02 15:04"), escenario.devolucion.Format("2006-01-02 15:04"))
+	require.NoError(t, page.NavigateTo(url))
 	require.NoError(t, page.AcceptCookies())
-	require.NoError(t, page.SearchVehicles(time.Now(), time.Now(), pickupLocation, returnLocation))
+	results, err := page.SearchVehicles(escenario.criterio())
+	require.NoError(t, err)
 	
 	// Verificar que el título de la página de coches disponibles contiene el texto esperado
     expectedTitle := "Resultados Búsqueda"
-	actualTitle, err := page.Title()
+	actualTitle, err := results.Title()
     require.NoError(t, err)
     require.Contains(t, actualTitle, expectedTitle, "El título de la página de resultados no es el esperado")
 	logger.Printf("📝 Título obtenido: %s", actualTitle)
 
 	// Verificar que se han encontrado vehículos
-	vehicles, err := page.AvailableVehicles()
+	vehicles, err := results.Vehicles()
 	require.NoError(t, err)
 	require.Greater(t, len(vehicles), 0, "❌ No se han encontrado vehículos disponibles")
 	logger.Printf("🚗 Vehículos encontrados: %d", len(vehicles))
+	for _, vehicle := range vehicles {
+		require.NotEmpty(t, vehicle.Model, "❌ Vehículo sin modelo: %q", vehicle.Raw)
+		if !vehicle.Available {
+			continue
+		}
+		price := vehicle.LowestPrice()
+		require.Greater(t, price.Amount, 0.0, "❌ Vehículo %s sin precio", vehicle.Model)
+		require.Equal(t, "EUR", price.Currency, "❌ Moneda inesperada para %s", vehicle.Model)
+		logger.Printf("   • %s (%s) %s", vehicle.Model, vehicle.Category, price)
+	}
 	
 	logger.Printf("✅ Test de búsqueda completado en %.2f", time.Since(startTime).Seconds())
+	return results
 }
 
 
@@ -52,4 +116,327 @@ func TestAvisPage(t *testing.T) {
     t.Run("should search for a vehicle", func(t *testing.T) {
         verificarBusquedaAvis(avisPage, t)
     })
+
+    for _, escenario := range escenariosAvis(time.Now())[1:] {
+        escenario := escenario
+        t.Run("should search for a vehicle: "+escenario.nombre, func(t *testing.T) {
+            verificarEscenarioAvis(avisPage, t, escenario)
+        })
+    }
+
+    t.Run("should reject return before pickup", func(t *testing.T) {
+        recogida := proximoDia(time.Now(), time.Monday, 10)
+        _, err := avisPage.SearchVehicles(escenarioAvis{recogida: recogida, devolucion: recogida.Add(-time.Hour), devolucionEn: returnLocation}.criterio())
+        require.Error(t, err, "❌ La devolución anterior a la recogida debe rechazarse")
+    })
+
+    t.Run("should sort results by price", func(t *testing.T) {
+        results := verificarBusquedaAvis(avisPage, t)
+        require.NoError(t, results.SortBy(pages.SortPriceAscending))
+
+        vehicles, err := results.Vehicles()
+        require.NoError(t, err)
+        require.True(t, pages.SortedByPrice(vehicles, true), "❌ Los resultados no están ordenados por precio ascendente")
+    })
+
+    t.Run("should narrow results with filters", func(t *testing.T) {
+        results := verificarBusquedaAvis(avisPage, t)
+        total, err := results.ResultCount()
+        require.NoError(t, err)
+
+        require.NoError(t, results.FilterByTransmission(pages.TransmissionAutomatic))
+        filtered, err := results.ResultCount()
+        require.NoError(t, err)
+        require.LessOrEqual(t, filtered, total, "❌ El filtro no debe ampliar los resultados")
+
+        active, err := results.ActiveFilters()
+        require.NoError(t, err)
+        logger.Printf("🔎 Filtros activos: %v (%d de %d vehículos)", active, filtered, total)
+
+        vehicles, err := results.Vehicles()
+        require.NoError(t, err)
+        for _, vehicle := range vehicles {
+            require.NotEqual(t, pages.TransmissionManual, vehicle.Transmission, "❌ %s no debería pasar el filtro", vehicle.Model)
+        }
+
+        require.NoError(t, results.ClearFilters())
+    })
+
+    t.Run("should keep the quoted price through the booking funnel", func(t *testing.T) {
+        results := verificarBusquedaAvis(avisPage, t)
+        vehicles, err := results.Vehicles()
+        require.NoError(t, err)
+
+        indice := slices.IndexFunc(vehicles, func(v pages.Vehicle) bool { return v.Available && !v.LowestPrice().IsZero() })
+        require.NotEqual(t, -1, indice, "❌ No hay vehículos disponibles con precio")
+        vehiculo := vehicles[indice]
+        logger.Printf("🚗 Vehículo seleccionado: %s (ahora %s, después %s)", vehiculo.Model, vehiculo.PayNow, vehiculo.PayLater)
+
+        extras, err := results.SelectVehicleAt(indice)
+        require.NoError(t, err)
+
+        lineas, err := extras.Extras()
+        require.NoError(t, err)
+        var seleccionados []pages.LineItem
+        for _, linea := range lineas {
+            if linea.Selected {
+                seleccionados = append(seleccionados, linea)
+            }
+        }
+        totalExtras, err := extras.Total()
+        require.NoError(t, err)
+        base := totalExtras.Amount - pages.SumLineItems(seleccionados).Amount
+        require.True(t,
+            math.Abs(base-vehiculo.PayNow.Amount) < 0.01 || math.Abs(base-vehiculo.PayLater.Amount) < 0.01,
+            "❌ El precio base en extras (%.2f) no coincide con el de resultados (%s / %s)", base, vehiculo.PayNow, vehiculo.PayLater)
+
+        conductor, err := extras.Continue()
+        require.NoError(t, err)
+        require.NoError(t, conductor.Fill(pages.DriverDetails{FirstName: "Prueba", LastName: "Automatizada", Email: "qa@example.com", Phone: "600000000"}))
+
+        resumen, err := conductor.Continue()
+        require.NoError(t, err)
+        totalResumen, err := resumen.Total()
+        require.NoError(t, err)
+        require.InDelta(t, totalExtras.Amount, totalResumen.Amount, 0.01, "❌ El total del resumen no coincide con el de extras")
+        logger.Printf("💶 Total en extras %s, total en resumen %s", totalExtras, totalResumen)
+
+        var guardia *pages.PaymentGuardError
+        require.ErrorAs(t, resumen.Click("Pagar"), &guardia, "❌ El guardia debe impedir pulsar el pago")
+        require.Empty(t, extras.Guard().Blocked(), "❌ Se ha intentado enviar una petición de pago")
+    })
+
+    t.Run("should list location suggestions", func(t *testing.T) {
+        require.NoError(t, avisPage.NavigateTo(urlAvis))
+        require.NoError(t, avisPage.AcceptCookies())
+
+        suggestions, err := avisPage.ListLocationSuggestions("Barajas")
+        require.NoError(t, err)
+        require.NotEmpty(t, suggestions, "❌ El autocompletado no ha mostrado sugerencias")
+        logger.Printf("📍 Sugerencias para \"Barajas\": %v", suggestions)
+
+        _, err = pages.MatchSuggestion(suggestions, "madrid barajas adolfo suarez")
+        require.NoError(t, err, "❌ La ubicación de Barajas no aparece entre las sugerencias")
+    })
+}
+
+func TestMatchLocationSuggestion(t *testing.T) {
+    suggestions := []string{
+        "Madrid Atocha Estación AVE - ESP",
+        "Madrid-Barajas Adolfo Suárez T1 y T4 - ESP",
+        "Barcelona-El Prat T1 y T2 - ESP",
+    }
+
+    casos := []struct {
+        nombre   string
+        buscado  string
+        esperado int
+    }{
+        {"exacta", pickupLocation, 1},
+        {"sin acentos ni mayúsculas", "madrid-barajas adolfo suarez t1 y t4 - esp", 1},
+        {"prefijo", "Barcelona", 2},
+        {"contenido", "Atocha", 0},
+        {"por palabras", "Suarez Barajas Madrid", 1},
+    }
+    for _, caso := range casos {
+        t.Run(caso.nombre, func(t *testing.T) {
+            index, err := pages.MatchSuggestion(suggestions, caso.buscado)
+            require.NoError(t, err)
+            require.Equal(t, caso.esperado, index)
+        })
+    }
+
+    t.Run("sin coincidencias", func(t *testing.T) {
+        _, err := pages.MatchSuggestion(suggestions, "Valencia Aeropuerto")
+        require.Error(t, err)
+        require.Contains(t, err.Error(), "Barcelona-El Prat", "❌ El error debe listar todas las sugerencias")
+    })
+}
+
+func TestParseVehicle(t *testing.T) {
+    tarjeta := `Compacto
+Volkswagen Golf o similar
+Grupo C · CDMR
+Manual
+5 plazas
+5 puertas
+2 maletas
+Kilometraje ilimitado
+Pagar ahora
+1.234,56 €
+Pagar en el mostrador
+1.310,00 €`
+
+    vehicle := pages.ParseVehicle(tarjeta)
+    require.Equal(t, "Volkswagen Golf", vehicle.Model)
+    require.True(t, vehicle.OrSimilar)
+    require.Equal(t, "Compacto", vehicle.Category)
+    require.Equal(t, "C", vehicle.Group)
+    require.Equal(t, "CDMR", vehicle.ACRISS)
+    require.Equal(t, pages.TransmissionManual, vehicle.Transmission)
+    require.Equal(t, 5, vehicle.Seats)
+    require.Equal(t, 5, vehicle.Doors)
+    require.Equal(t, 2, vehicle.Luggage)
+    require.True(t, vehicle.UnlimitedMileage)
+    require.Equal(t, pages.Price{Amount: 1234.56, Currency: "EUR"}, vehicle.PayNow)
+    require.Equal(t, pages.Price{Amount: 1310, Currency: "EUR"}, vehicle.PayLater)
+    require.Equal(t, vehicle.PayNow, vehicle.LowestPrice())
+    require.True(t, vehicle.Available)
+
+    agotado := pages.ParseVehicle("Fiat 500 o similar\nAutomático\n200 km incluidos\nNo disponible")
+    require.Equal(t, pages.TransmissionAutomatic, agotado.Transmission)
+    require.Equal(t, 200, agotado.MileageLimit)
+    require.False(t, agotado.Available)
+    require.True(t, agotado.LowestPrice().IsZero())
+}
+
+func TestParsePrice(t *testing.T) {
+    casos := map[string]pages.Price{
+        "45,90 €":          {Amount: 45.9, Currency: "EUR"},
+        "Total: 1.045 EUR": {Amount: 1045, Currency: "EUR"},
+        "€ 12.345,6":       {Amount: 12345.6, Currency: "EUR"},
+        "£99":              {Amount: 99, Currency: "GBP"},
+    }
+    for texto, esperado := range casos {
+        price, err := pages.ParsePrice(texto)
+        require.NoError(t, err, texto)
+        require.Equal(t, esperado, price, texto)
+    }
+
+    _, err := pages.ParsePrice("3 puertas")
+    require.Error(t, err)
+}
+
+func TestSearchCriteria(t *testing.T) {
+    recogida := proximoDia(time.Now(), time.Monday, 10)
+    criterio := escenarioAvis{recogida: recogida, devolucion: recogida.AddDate(0, 0, 2)}.criterio()
+    require.NoError(t, criterio.Validate())
+    require.False(t, criterio.OneWay(), "❌ Sin oficina de devolución la búsqueda es de ida y vuelta")
+
+    criterio.ReturnLocation = "madrid-barajas adolfo suarez t1 y t4 - esp"
+    require.False(t, criterio.OneWay(), "❌ La misma oficina escrita de otra forma no es un trayecto de ida")
+
+    criterio.ReturnLocation = returnLocation
+    require.True(t, criterio.OneWay())
+
+    invalidos := map[string]struct {
+        modificar func(c *pages.SearchCriteria)
+        campo     string
+    }{
+        "sin recogida":        {func(c *pages.SearchCriteria) { c.PickupLocation = " " }, pages.FieldPickupLocation},
+        "sin fechas":          {func(c *pages.SearchCriteria) { c.ReturnTime = time.Time{} }, pages.FieldReturnDate},
+        "devolución anterior": {func(c *pages.SearchCriteria) { c.ReturnTime = c.PickupTime }, pages.FieldReturnDate},
+        "recogida pasada":     {func(c *pages.SearchCriteria) { c.PickupTime = time.Now().AddDate(0, 0, -1) }, pages.FieldPickupDate},
+        "conductor menor":     {func(c *pages.SearchCriteria) { c.DriverAge = 17 }, pages.FieldDriverAge},
+    }
+    for nombre, caso := range invalidos {
+        c := criterio
+        caso.modificar(&c)
+        validationErrors, ok := pages.AsValidationErrors(c.Validate())
+        require.True(t, ok, nombre)
+        require.Len(t, validationErrors, 1, nombre)
+        require.Equal(t, caso.campo, validationErrors[0].Field, nombre)
+        require.Equal(t, pages.ValidationClient, validationErrors[0].Source, nombre)
+    }
+
+    vacio, ok := pages.AsValidationErrors(pages.SearchCriteria{}.Validate())
+    require.True(t, ok)
+    require.Len(t, vacio, 3, "❌ Un criterio vacío debe informar de recogida, fecha de recogida y fecha de devolución")
+    require.Equal(t, "La oficina de recogida es obligatoria", vacio.ForField(pages.FieldPickupLocation)[0].Message)
+}
+
+// verificarBusquedaRechazada envía una búsqueda inválida y comprueba que el sitio o el widget la rechazan
+func verificarBusquedaRechazada(page *pages.AvisPage, t *testing.T, criterio pages.SearchCriteria, campo string) {
+    verificarBusquedaRechazadaEn(page, t, urlAvis, criterio, campo)
+}
+
+// verificarBusquedaRechazadaEn envía la búsqueda inválida en la URL indicada, real o réplica local
+func verificarBusquedaRechazadaEn(page *pages.AvisPage, t *testing.T, url string, criterio pages.SearchCriteria, campo string) {
+    require.NoError(t, page.NavigateTo(url))
+    require.NoError(t, page.AcceptCookies())
+
+    validationErrors, err := page.SubmitSearch(criterio)
+    require.NoError(t, err)
+    require.NotEmpty(t, validationErrors, "❌ La búsqueda inválida ha llegado a la página de resultados")
+    for _, validationError := range validationErrors {
+        logger.Printf("⚠️ %s", validationError)
+    }
+    if campo != "" {
+        require.NotEmpty(t, validationErrors.ForField(campo), "❌ Ningún error asociado al campo %s", campo)
+    }
+}
+
+func TestAvisSearchValidation(t *testing.T) {
+    avisPage := pages.NewAvisPage()
+    defer avisPage.Close()
+
+    recogida := proximoDia(time.Now(), time.Monday, 10)
+    valido := escenarioAvis{recogida: recogida, devolucion: recogida.AddDate(0, 0, 3), devolucionEn: returnLocation}.criterio()
+
+    t.Run("should reject return before pickup", func(t *testing.T) {
+        criterio := valido
+        criterio.ReturnTime = recogida.AddDate(0, 0, -1)
+        verificarBusquedaRechazada(avisPage, t, criterio, "")
+    })
+
+    t.Run("should reject unknown location", func(t *testing.T) {
+        criterio := valido
+        criterio.PickupLocation = "Ubicación inexistente XYZ"
+        verificarBusquedaRechazada(avisPage, t, criterio, pages.FieldPickupLocation)
+    })
+
+    t.Run("should reject empty fields", func(t *testing.T) {
+        verificarBusquedaRechazada(avisPage, t, pages.SearchCriteria{}, pages.FieldPickupLocation)
+    })
+
+    t.Run("should reject past dates", func(t *testing.T) {
+        criterio := valido
+        criterio.PickupTime = time.Now().AddDate(0, 0, -3)
+        verificarBusquedaRechazada(avisPage, t, criterio, pages.FieldPickupDate)
+    })
+
+    t.Run("should reject drivers under the minimum age", func(t *testing.T) {
+        criterio := valido
+        criterio.DriverAge = pages.MinDriverAge - 1
+        verificarBusquedaRechazada(avisPage, t, criterio, pages.FieldDriverAge)
+    })
+}
+
+func TestAvisResultsHelpers(t *testing.T) {
+    count, ok := pages.ParseResultCount("Mostrando 24 vehículos disponibles")
+    require.True(t, ok)
+    require.Equal(t, 24, count)
+
+    count, ok = pages.ParseResultCount("1 vehículo disponible")
+    require.True(t, ok)
+    require.Equal(t, 1, count)
+
+    _, ok = pages.ParseResultCount("Sin resultados")
+    require.False(t, ok)
+
+    conPrecio := func(importe float64) pages.Vehicle {
+        return pages.Vehicle{Available: true, PayNow: pages.Price{Amount: importe, Currency: "EUR"}}
+    }
+    ordenados := []pages.Vehicle{conPrecio(30), {Available: false}, conPrecio(45.5), conPrecio(45.5), conPrecio(120)}
+    require.True(t, pages.SortedByPrice(ordenados, true))
+    require.False(t, pages.SortedByPrice(ordenados, false))
+}
+
+func TestBookingFunnelHelpers(t *testing.T) {
+    for _, control := range []string{"Pagar ahora", "CONFIRMAR RESERVA", "Reservar ahora", "Pay now", "Finalizar"} {
+        require.True(t, pages.IsFinalAction(control), control)
+    }
+    for _, control := range []string{"Continuar", "Seleccionar", "Añadir extras", "Volver"} {
+        require.False(t, pages.IsFinalAction(control), control)
+    }
+
+    linea, err := pages.ParseLineItem("Silla infantil\n  45,00 €")
+    require.NoError(t, err)
+    require.Equal(t, pages.LineItem{Name: "Silla infantil", Price: pages.Price{Amount: 45, Currency: "EUR"}}, linea)
+
+    total := pages.SumLineItems([]pages.LineItem{linea, {Name: "GPS", Price: pages.Price{Amount: 12.5, Currency: "EUR"}}})
+    require.Equal(t, pages.Price{Amount: 57.5, Currency: "EUR"}, total)
+
+    _, err = pages.ParseLineItem("Kilometraje ilimitado incluido")
+    require.Error(t, err)
 }
@@ -0,0 +1,264 @@
+// tests/e2e/avis_replica_test.go
+
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/replica"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// avisLocal arranca una réplica local del buscador de Avis y devuelve su URL
+func avisLocal(t *testing.T, opts replica.AvisOptions) string {
+	server := replica.NewAvisServer(opts)
+	t.Cleanup(server.Close)
+	logger.Printf("🏠 Réplica local de Avis en %s", server.URL)
+	return server.URL + "/"
+}
+
+// sugerenciasLocales pide a la réplica las ubicaciones que sugiere para la consulta
+func sugerenciasLocales(t *testing.T, url, consulta string) []string {
+	resp, err := http.Get(url + "api/locations?q=" + strings.ReplaceAll(consulta, " ", "+"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var sugerencias []string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&sugerencias))
+	return sugerencias
+}
+
+func TestAvisReplicaStatic(t *testing.T) {
+	url := avisLocal(t, replica.AvisOptions{})
+
+	t.Run("should serve search form", func(t *testing.T) {
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "<title>"+expectedTitleAvis+"</title>")
+		assert.Contains(t, string(body), `id="getAQuote"`)
+	})
+
+	t.Run("should suggest locations ignoring accents", func(t *testing.T) {
+		assert.Equal(t, []string{pickupLocation}, sugerenciasLocales(t, url, "barajas adolfo suarez"))
+		assert.Len(t, sugerenciasLocales(t, url, "madrid"), 3)
+		assert.Equal(t, []string{"Málaga-Costa del Sol Aeropuerto - ESP"}, sugerenciasLocales(t, url, "malaga"))
+		assert.Empty(t, sugerenciasLocales(t, url, "m"), "❌ Con una sola letra no se sugiere nada")
+		assert.Empty(t, sugerenciasLocales(t, url, "Ubicación inexistente XYZ"))
+	})
+
+	t.Run("should expose configuration", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{
+			NoConsentBanner: true,
+			FieldErrors:     map[string]string{replica.AvisPickupField: "Oficina cerrada"},
+		})
+		resp, err := http.Get(url + "config.js")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"consentBanner":false`)
+		assert.Contains(t, string(body), `"hire-search":"Oficina cerrada"`)
+		assert.Contains(t, string(body), `"model":"Seat Ibiza"`)
+	})
+
+	t.Run("should delay suggestions", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{SuggestionDelay: 300 * time.Millisecond})
+		inicio := time.Now()
+		sugerenciasLocales(t, url, "madrid")
+		assert.GreaterOrEqual(t, time.Since(inicio), 300*time.Millisecond)
+	})
+}
+
+func TestAvisReplica(t *testing.T) {
+	url := avisLocal(t, replica.AvisOptions{SuggestionDelay: 200 * time.Millisecond})
+	avisPage := pages.NewAvisPage()
+	defer avisPage.Close()
+
+	// Los mismos escenarios que contra avis.es
+	for _, escenario := range escenariosAvis(time.Now()) {
+		escenario := escenario
+		t.Run("should search for a vehicle: "+escenario.nombre, func(t *testing.T) {
+			verificarEscenarioAvisEn(avisPage, t, url, escenario)
+		})
+	}
+
+	t.Run("should list location suggestions", func(t *testing.T) {
+		require.NoError(t, avisPage.NavigateTo(url))
+		require.NoError(t, avisPage.AcceptCookies())
+		sugerencias, err := avisPage.ListLocationSuggestions("Madrid")
+		require.NoError(t, err)
+		assert.Len(t, sugerencias, 3)
+		assert.Contains(t, sugerencias, pickupLocation)
+	})
+
+	t.Run("should parse vehicles and prices", func(t *testing.T) {
+		recogida := proximoDia(time.Now(), time.Monday, 10)
+		results := verificarEscenarioAvisEn(avisPage, t, url, escenarioAvis{nombre: "tres días", recogida: recogida, devolucion: recogida.AddDate(0, 0, 3)})
+
+		vehicles, err := results.Vehicles()
+		require.NoError(t, err)
+		require.Len(t, vehicles, len(replica.DefaultAvisVehicles))
+		for i, esperado := range replica.DefaultAvisVehicles {
+			vehicle := vehicles[i]
+			assert.Equal(t, esperado.Model, vehicle.Model)
+			assert.Equal(t, esperado.Category, vehicle.Category)
+			assert.Equal(t, esperado.Group, vehicle.Group)
+			assert.Equal(t, esperado.ACRISS, vehicle.ACRISS)
+			assert.Equal(t, esperado.Seats, vehicle.Seats)
+			assert.Equal(t, esperado.MileageLimit == 0, vehicle.UnlimitedMileage)
+			assert.Equal(t, !esperado.Unavailable, vehicle.Available)
+			if vehicle.Available {
+				assert.InDelta(t, esperado.PayNowPerDay*3, vehicle.PayNow.Amount, 0.01, "❌ Precio de %s", vehicle.Model)
+				assert.InDelta(t, esperado.PayLaterPerDay*3, vehicle.PayLater.Amount, 0.01, "❌ Precio de %s", vehicle.Model)
+			}
+		}
+
+		count, err := results.ResultCount()
+		require.NoError(t, err)
+		assert.Equal(t, len(vehicles), count)
+	})
+
+	t.Run("should filter and sort results", func(t *testing.T) {
+		results := verificarEscenarioAvisEn(avisPage, t, url, escenariosAvis(time.Now())[3])
+
+		require.NoError(t, results.FilterByTransmission(pages.TransmissionAutomatic))
+		vehicles, err := results.Vehicles()
+		require.NoError(t, err)
+		require.Len(t, vehicles, 2)
+		for _, vehicle := range vehicles {
+			assert.Equal(t, pages.TransmissionAutomatic, vehicle.Transmission)
+		}
+		filtros, err := results.ActiveFilters()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Automático"}, filtros)
+
+		require.NoError(t, results.ClearFilters())
+		require.NoError(t, results.FilterByCategory("Compacto"))
+		vehicles, err = results.Vehicles()
+		require.NoError(t, err)
+		assert.Len(t, vehicles, 2)
+
+		require.NoError(t, results.ClearFilters())
+		require.NoError(t, results.SortBy(pages.SortPriceDescending))
+		vehicles, err = results.Vehicles()
+		require.NoError(t, err)
+		assert.True(t, pages.SortedByPrice(vehicles, false), "❌ Los resultados no están ordenados de mayor a menor precio")
+		require.NoError(t, results.SortBy(pages.SortPriceAscending))
+		vehicles, err = results.Vehicles()
+		require.NoError(t, err)
+		assert.True(t, pages.SortedByPrice(vehicles, true), "❌ Los resultados no están ordenados de menor a mayor precio")
+	})
+}
+
+func TestAvisReplicaValidation(t *testing.T) {
+	avisPage := pages.NewAvisPage()
+	defer avisPage.Close()
+
+	recogida := proximoDia(time.Now(), time.Monday, 10)
+	valido := escenarioAvis{recogida: recogida, devolucion: recogida.AddDate(0, 0, 3), devolucionEn: returnLocation}.criterio()
+
+	// Las mismas búsquedas inválidas que contra avis.es
+	url := avisLocal(t, replica.AvisOptions{})
+	t.Run("should reject return before pickup", func(t *testing.T) {
+		criterio := valido
+		criterio.ReturnTime = recogida.AddDate(0, 0, -1)
+		verificarBusquedaRechazadaEn(avisPage, t, url, criterio, pages.FieldReturnDate)
+	})
+	t.Run("should reject unknown location", func(t *testing.T) {
+		criterio := valido
+		criterio.PickupLocation = "Ubicación inexistente XYZ"
+		verificarBusquedaRechazadaEn(avisPage, t, url, criterio, pages.FieldPickupLocation)
+	})
+	t.Run("should reject empty fields", func(t *testing.T) {
+		verificarBusquedaRechazadaEn(avisPage, t, url, pages.SearchCriteria{}, pages.FieldPickupLocation)
+	})
+	t.Run("should reject past dates", func(t *testing.T) {
+		criterio := valido
+		criterio.PickupTime = time.Now().AddDate(0, 0, -3)
+		verificarBusquedaRechazadaEn(avisPage, t, url, criterio, pages.FieldPickupDate)
+	})
+
+	// Y los mensajes del sitio, que en la réplica se eligen con AvisOptions
+	t.Run("should report field errors", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{FieldErrors: map[string]string{
+			replica.AvisPickupField:    "La oficina está cerrada en esa fecha.",
+			replica.AvisDriverAgeField: "Se aplicará un suplemento por conductor joven.",
+		}})
+		require.NoError(t, avisPage.NavigateTo(url))
+		require.NoError(t, avisPage.AcceptCookies())
+
+		validationErrors, err := avisPage.SubmitSearch(valido)
+		require.NoError(t, err)
+		pickup := validationErrors.ForField(pages.FieldPickupLocation)
+		require.Len(t, pickup, 1, "❌ Errores: %v", validationErrors)
+		assert.Equal(t, "La oficina está cerrada en esa fecha.", pickup[0].Message)
+		assert.Equal(t, pages.ValidationInline, pickup[0].Source)
+		assert.Len(t, validationErrors.ForField(pages.FieldDriverAge), 1, "❌ Errores: %v", validationErrors)
+	})
+
+	t.Run("should report banner errors", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{BannerError: "Servicio no disponible. Inténtalo más tarde."})
+		require.NoError(t, avisPage.NavigateTo(url))
+		require.NoError(t, avisPage.AcceptCookies())
+
+		_, err := avisPage.SearchVehicles(valido)
+		validationErrors, ok := pages.AsValidationErrors(err)
+		require.True(t, ok, "❌ Se esperaban errores de validación: %v", err)
+		require.Len(t, validationErrors, 1)
+		assert.Equal(t, pages.ValidationBanner, validationErrors[0].Source)
+		assert.Equal(t, "Servicio no disponible. Inténtalo más tarde.", validationErrors[0].Message)
+	})
+}
+
+func TestAvisReplicaResults(t *testing.T) {
+	avisPage := pages.NewAvisPage()
+	defer avisPage.Close()
+	recogida := proximoDia(time.Now(), time.Monday, 10)
+	escenario := escenarioAvis{nombre: "sin banner", recogida: recogida, devolucion: recogida.AddDate(0, 0, 2)}
+
+	t.Run("should show empty results", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{EmptyResults: true, NoConsentBanner: true})
+		require.NoError(t, avisPage.NavigateTo(url))
+		results, err := avisPage.SearchVehicles(escenario.criterio())
+		require.NoError(t, err)
+
+		vehicles, err := results.Vehicles()
+		require.NoError(t, err)
+		assert.Empty(t, vehicles)
+		count, err := results.ResultCount()
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("should wait for slow results", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{ResultsDelay: 3 * time.Second, NoConsentBanner: true})
+		inicio := time.Now()
+		verificarEscenarioAvisEn(avisPage, t, url, escenario)
+		assert.GreaterOrEqual(t, time.Since(inicio), 3*time.Second)
+	})
+
+	t.Run("should use custom vehicles", func(t *testing.T) {
+		url := avisLocal(t, replica.AvisOptions{NoConsentBanner: true, Vehicles: []replica.AvisVehicle{
+			{Model: "Tesla Model 3", Category: "Eléctrico", Group: "E", ACRISS: "IDAE", Automatic: true, Seats: 5, Doors: 4, Luggage: 2, PayNowPerDay: 120, PayLaterPerDay: 132.5},
+		}})
+		results := verificarEscenarioAvisEn(avisPage, t, url, escenario)
+		vehicles, err := results.Vehicles()
+		require.NoError(t, err)
+		require.Len(t, vehicles, 1)
+		assert.Equal(t, "Tesla Model 3", vehicles[0].Model)
+		assert.Equal(t, pages.TransmissionAutomatic, vehicles[0].Transmission)
+		assert.InDelta(t, 240.0, vehicles[0].PayNow.Amount, 0.01)
+	})
+}
@@ -0,0 +1,62 @@
+// tests/e2e/baselines_test.go
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/baselines"
+	"GoLang_FRT_E2E_Tests/pkg/snapshot"
+	"GoLang_FRT_E2E_Tests/pkg/visual"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaselinesManagement(t *testing.T) {
+	root := t.TempDir()
+
+	// Una ejecución fallida deja el resultado "actual" junto a la referencia
+	store := snapshot.NewStore(baselines.Dir(root, "prod", baselines.KindSnapshot))
+	store.Update = true
+	_, err := store.Assert("home", &snapshot.Page{Title: "Free Range Testers", Links: []string{"/cursos"}})
+	require.NoError(t, err)
+	store.Update = false
+	_, err = store.Assert("home", &snapshot.Page{Title: "Free Range Testers", Links: []string{"/cursos", "/blog"}})
+	require.Error(t, err)
+
+	// Una captura sin referencia aprobada solo tiene el resultado pendiente
+	actualPath := filepath.Join(baselines.Dir(root, "staging", baselines.KindVisual), "sandbox"+visual.ActualSuffix)
+	require.NoError(t, os.MkdirAll(filepath.Dir(actualPath), 0755))
+	require.NoError(t, os.WriteFile(actualPath, []byte("png"), 0644))
+
+	entries, err := baselines.List(root, baselines.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "prod", entries[0].Env)
+	assert.True(t, entries[0].Pending(), "❌ La instantánea debe quedar pendiente de revisión")
+	assert.Equal(t, baselines.KindVisual, entries[1].Kind)
+	assert.Empty(t, entries[1].Path)
+	assert.True(t, entries[1].Pending())
+
+	entries, err = baselines.List(root, baselines.Filter{Env: "prod", Kind: baselines.KindSnapshot, Name: "home"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	approved, err := baselines.Approve(entries[0])
+	require.NoError(t, err)
+	assert.False(t, approved.Pending())
+	golden, err := store.Load("home")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/cursos", "/blog"}, golden.Links, "❌ El resultado aprobado debe ser la nueva referencia")
+
+	_, err = baselines.Approve(approved)
+	assert.Error(t, err, "❌ No se puede aprobar sin resultado pendiente")
+
+	require.NoError(t, baselines.Remove(entries[0]))
+	entries, err = baselines.List(root, baselines.Filter{Env: "prod"})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,166 @@
+// tests/e2e/consent_test.go
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/consent"
+
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+const consentProvidersFile = "testdata/consent/providers.yaml"
+
+// consentBannerPage reproduce un banner de Cookiebot que registra la decisión en window.consent
+const consentBannerPage = `<html><body>
+<div id="CybotCookiebotDialog">
+	<button id="CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll" onclick="decide('accept')">Permitir todas</button>
+	<button id="CybotCookiebotDialogBodyButtonDecline" onclick="decide('reject')">Rechazar</button>
+	<button id="CybotCookiebotDialogBodyLevelButtonCustomize" onclick="document.getElementById('panel').style.display='block'">Personalizar</button>
+	<div id="panel" style="display:none">
+		<input type="checkbox" id="CybotCookiebotDialogBodyLevelButtonPreferences">
+		<input type="checkbox" id="CybotCookiebotDialogBodyLevelButtonStatistics">
+		<input type="checkbox" id="CybotCookiebotDialogBodyLevelButtonMarketing" checked>
+		<button id="CybotCookiebotDialogBodyLevelButtonLevelOptinAllowallSelection" onclick="decide(selection())">Guardar</button>
+	</div>
+</div>
+<script>
+	window.consent = '';
+	function selection() {
+		return ['Preferences', 'Statistics', 'Marketing']
+			.filter(c => document.getElementById('CybotCookiebotDialogBodyLevelButton' + c).checked)
+			.join(',') || 'none';
+	}
+	function decide(value) {
+		window.consent = value;
+		document.getElementById('CybotCookiebotDialog').style.display = 'none';
+	}
+</script>
+</body></html>`
+
+// consentServer sirve la página con banner en / y una página sin banner en /plain
+func consentServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plain" {
+			w.Write([]byte(`<html><body><script>window.consent = ''</script>sin banner</body></html>`))
+			return
+		}
+		w.Write([]byte(consentBannerPage))
+	}))
+}
+
+// casosConsentimiento son las decisiones probadas y el valor que debe registrar la página
+var casosConsentimiento = []struct {
+	nombre   string
+	decision consent.Decision
+	esperado string
+}{
+	{"accept", consent.Accept(), "accept"},
+	{"reject", consent.Reject(), "reject"},
+	{"customize", consent.Customize(map[string]bool{consent.CategoryStatistics: true}), "Statistics"},
+}
+
+func TestConsentProviders(t *testing.T) {
+	providers, err := consent.LoadProviders(consentProvidersFile)
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	require.Equal(t, "frt-banner", providers[0].Name)
+	require.Equal(t, "#frt-cookies-statistics", providers[0].Categories[consent.CategoryStatistics])
+
+	t.Setenv("CONSENT_PROVIDERS", consentProvidersFile)
+	manager, err := consent.ManagerFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, "frt-banner", manager.Providers[0].Name, "❌ Los proveedores propios tienen prioridad")
+	require.Len(t, manager.Providers, len(consent.DefaultProviders)+1)
+
+	t.Setenv("CONSENT_PROVIDERS", "testdata/consent/missing.yaml")
+	_, err = consent.ManagerFromEnv()
+	require.Error(t, err)
+}
+
+func TestConsentChromedp(t *testing.T) {
+	server := consentServer()
+	defer server.Close()
+
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	manager := consent.NewManager()
+	for _, caso := range casosConsentimiento {
+		t.Run(caso.nombre, func(t *testing.T) {
+			var result consent.Result
+			var registrado string
+			require.NoError(t, chromedp.Run(ctx,
+				chromedp.Navigate(server.URL),
+				manager.Chromedp(caso.decision, &result),
+				chromedp.Evaluate(`window.consent`, &registrado),
+			))
+			require.Equal(t, consent.Cookiebot.Name, result.Provider)
+			require.Equal(t, caso.esperado, registrado)
+		})
+	}
+
+	t.Run("no banner", func(t *testing.T) {
+		manager := &consent.Manager{Providers: consent.DefaultProviders, Timeout: 500 * time.Millisecond}
+		var result consent.Result
+		require.NoError(t, chromedp.Run(ctx,
+			chromedp.Navigate(server.URL+"/plain"),
+			manager.Chromedp(consent.Accept(), &result),
+		), "❌ Que no aparezca el banner no debe ser un error")
+		require.False(t, result.Shown())
+	})
+}
+
+func TestConsentPlaywright(t *testing.T) {
+	server := consentServer()
+	defer server.Close()
+
+	pw, err := playwright.Run()
+	require.NoError(t, err)
+	defer pw.Stop()
+	browser, err := pw.Chromium.Launch()
+	require.NoError(t, err)
+	defer browser.Close()
+
+	manager := consent.NewManager()
+	for _, caso := range casosConsentimiento {
+		t.Run(caso.nombre, func(t *testing.T) {
+			page, err := browser.NewPage()
+			require.NoError(t, err)
+			defer page.Close()
+
+			_, err = page.Goto(server.URL)
+			require.NoError(t, err)
+			result, err := manager.Playwright(page, caso.decision)
+			require.NoError(t, err)
+			require.Equal(t, consent.Cookiebot.Name, result.Provider)
+
+			registrado, err := page.Evaluate(`window.consent`)
+			require.NoError(t, err)
+			require.Equal(t, caso.esperado, fmt.Sprint(registrado))
+		})
+	}
+
+	t.Run("no banner", func(t *testing.T) {
+		page, err := browser.NewPage()
+		require.NoError(t, err)
+		defer page.Close()
+
+		_, err = page.Goto(server.URL + "/plain")
+		require.NoError(t, err)
+		manager := &consent.Manager{Providers: consent.DefaultProviders, Timeout: 500 * time.Millisecond}
+		result, err := manager.Playwright(page, consent.Reject())
+		require.NoError(t, err, "❌ Que no aparezca el banner no debe ser un error")
+		require.False(t, result.Shown())
+	})
+}
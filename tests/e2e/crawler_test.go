@@ -0,0 +1,151 @@
+// tests/e2e/crawler_test.go
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/crawler"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func crawlerFixturePage(title string, hrefs ...string) string {
+	var body strings.Builder
+	for _, href := range hrefs {
+		fmt.Fprintf(&body, `<a href="%s">%s</a>`, href, href)
+	}
+	return fmt.Sprintf(`<!DOCTYPE html><html lang="es"><head><title>%s</title></head>
+<body><h1>%s</h1><div id="page_section_1"></div>%s</body></html>`, title, title, body.String())
+}
+
+func TestSiteCrawl(t *testing.T) {
+	// El enlace externo apunta a otro host local para que la verificación no dependa de la red
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer external.Close()
+	externalURL := strings.Replace(external.URL, "127.0.0.1", "localhost", 1) + "/"
+
+	sitePages := map[string]string{
+		"/":              crawlerFixturePage("Inicio", "/cursos", "/privado/admin", externalURL),
+		"/cursos":        crawlerFixturePage("Cursos", "/cursos/go", "/"),
+		"/cursos/go":     crawlerFixturePage("Go", "/cursos/go/avanzado"),
+		"/desde-sitemap": crawlerFixturePage(""),
+		"/privado/admin": crawlerFixturePage("Admin"),
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprintf(w, "User-agent: *\nDisallow: /privado\nSitemap: %s/sitemap.xml\n", server.URL)
+			return
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<urlset><url><loc>%s/desde-sitemap</loc></url></urlset>`, server.URL)
+			return
+		}
+		html, ok := sitePages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL + "/"
+
+	c := crawler.New(page.URL)
+	c.MaxDepth = 2
+	c.Delay = 0
+
+	inventory, err := c.Crawl(context.Background())
+	require.NoError(t, err)
+
+	visited := make(map[string]crawler.PageInfo)
+	for _, info := range inventory.Pages {
+		visited[strings.TrimPrefix(info.URL, server.URL)] = info
+	}
+
+	assert.Contains(t, visited, "/")
+	assert.Contains(t, visited, "/cursos")
+	assert.Contains(t, visited, "/cursos/go")
+	assert.Contains(t, visited, "/desde-sitemap", "❌ Debe incluir las páginas del sitemap")
+	assert.NotContains(t, visited, "/cursos/go/avanzado", "❌ No debe superar la profundidad máxima")
+	assert.Equal(t, []string{server.URL + "/privado/admin"}, inventory.Disallowed, "❌ Debe respetar robots.txt")
+
+	assert.Equal(t, "Cursos", visited["/cursos"].Title)
+	assert.Equal(t, 1, visited["/cursos"].SectionCount)
+	assert.Len(t, visited["/"].OutboundLinks, 3)
+
+	// Por defecto se verifican los enlaces de cada página
+	require.NotNil(t, visited["/"].LinkReport)
+	assert.Empty(t, visited["/"].LinkReport.Broken())
+	require.NotNil(t, visited["/cursos/go"].LinkReport)
+	assert.Len(t, visited["/cursos/go"].LinkReport.Broken(), 1, "❌ El enlace a una página inexistente debe estar roto")
+
+	failed := inventory.Failed()
+	require.Len(t, failed, 2, "❌ Deben fallar la página con un enlace roto y la página sin título")
+	assert.ElementsMatch(t, []string{server.URL + "/cursos/go", server.URL + "/desde-sitemap"}, []string{failed[0].URL, failed[1].URL})
+
+	// Con profundidad 0 solo se visita la página inicial, tampoco las del sitemap
+	c.MaxDepth = 0
+	inventory, err = c.Crawl(context.Background())
+	require.NoError(t, err)
+	require.Len(t, inventory.Pages, 1, "❌ Con MaxDepth=0 no se deben seguir enlaces ni el sitemap")
+	assert.Equal(t, page.URL, inventory.Pages[0].URL)
+}
+
+func TestParseRobots(t *testing.T) {
+	robots, err := crawler.ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /
+
+User-agent: FreeRangeTesters
+Disallow: /tmp/
+Disallow: /*.pdf$
+Allow: /tmp/publico
+
+User-agent: Free
+Disallow: /
+`), "FreeRangeTesters E2E Tests")
+	require.NoError(t, err)
+
+	assert.True(t, robots.Allowed("/cursos"))
+	assert.False(t, robots.Allowed("/tmp/borrador"))
+	assert.True(t, robots.Allowed("/tmp/publico/a"))
+	assert.False(t, robots.Allowed("/docs/guia.pdf"))
+	assert.True(t, robots.Allowed("/docs/guia.pdf?v=2"))
+
+	// Un token corto contenido en nuestro user agent no es nuestro grupo; sin grupo propio se usa "*"
+	robots, err = crawler.ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /privado
+
+User-agent: Tests
+Disallow: /
+`), "FreeRangeTesters E2E Tests")
+	require.NoError(t, err)
+	assert.True(t, robots.Allowed("/cursos"), "❌ El grupo 'Tests' no corresponde a nuestro product token")
+	assert.False(t, robots.Allowed("/privado"))
+
+	// La comparación no distingue mayúsculas y gana el grupo más específico
+	robots, err = crawler.ParseRobots(strings.NewReader(`
+User-agent: googlebot
+Disallow: /general
+
+User-agent: GOOGLEBOT-NEWS
+Disallow: /noticias
+`), "Googlebot-News/2.1")
+	require.NoError(t, err)
+	assert.True(t, robots.Allowed("/general"))
+	assert.False(t, robots.Allowed("/noticias"))
+}
@@ -0,0 +1,77 @@
+// tests/e2e/devices_test.go
+
+package e2e
+
+import (
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// variante añade el nombre de la sesión emulada al nombre del test, para que el
+// reporte muestre un resultado por dispositivo
+func variante(session emulation.Session) string {
+	if session.IsZero() {
+		return ""
+	}
+	return " [" + session.Name() + "]"
+}
+
+// porDispositivo ejecuta fn como subtest para cada dispositivo de la matriz
+func porDispositivo(t *testing.T, fn func(t *testing.T, session emulation.Session)) {
+	devices, err := emulation.MatrixFromEnv()
+	require.NoError(t, err)
+
+	for _, device := range devices {
+		device := device
+		t.Run(device.Name, func(t *testing.T) {
+			fn(t, emulation.Session{Device: &device})
+		})
+	}
+}
+
+func TestAvisPageDevices(t *testing.T) {
+	porDispositivo(t, func(t *testing.T, session emulation.Session) {
+		avisPage := pages.NewAvisPageWithSession(session)
+		defer avisPage.Close()
+		verificarBusquedaAvis(avisPage, t)
+	})
+}
+
+func TestSandboxPageDevices(t *testing.T) {
+	porDispositivo(t, func(t *testing.T, session emulation.Session) {
+		page := pages.NewSandboxPage()
+		page.Session = session
+		verificarBotonDinamico(page, t)
+	})
+}
+
+func TestDeviceMatrix(t *testing.T) {
+	t.Setenv("DEVICES", "iphone-13, desktop-1920")
+	devices, err := emulation.MatrixFromEnv()
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, emulation.IPhone13, devices[0])
+
+	t.Setenv("DEVICES", "nokia-3310")
+	_, err = emulation.MatrixFromEnv()
+	assert.Error(t, err, "❌ Un dispositivo desconocido debe fallar")
+
+	session := emulation.Session{Device: &emulation.Pixel7}
+	opts := session.PlaywrightOptions()
+	require.NotNil(t, opts.Viewport)
+	assert.Equal(t, 412, opts.Viewport.Width)
+	assert.Equal(t, 915, opts.Viewport.Height)
+	assert.Equal(t, *opts.Viewport, *opts.Screen, "❌ Playwright debe emular la misma pantalla que chromedp")
+	assert.Equal(t, emulation.Pixel7.UserAgent, *opts.UserAgent)
+	assert.Equal(t, 2.625, *opts.DeviceScaleFactor)
+	assert.True(t, *opts.IsMobile)
+	assert.True(t, *opts.HasTouch)
+	assert.Len(t, session.ChromedpActions(), 2, "❌ Debe emular viewport y user agent")
+	assert.Equal(t, " [Pixel 7]", variante(session))
+	assert.Empty(t, variante(emulation.Session{}))
+}
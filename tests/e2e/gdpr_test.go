@@ -0,0 +1,147 @@
+// tests/e2e/gdpr_test.go
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/gdpr"
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+const gdprTrackersFile = "testdata/gdpr/trackers.yaml"
+
+// reglasGDPR cuenta las incidencias por regla
+func reglasGDPR(issues []lint.Issue) map[string]int {
+	reglas := make(map[string]int)
+	for _, issue := range issues {
+		reglas[issue.Rule]++
+	}
+	return reglas
+}
+
+func TestGDPRAudit(t *testing.T) {
+	observaciones := []gdpr.Observation{
+		{
+			Phase: gdpr.PhaseBeforeConsent,
+			Requests: []gdpr.Request{
+				{URL: "https://static.avis.es/app.js", Host: "static.avis.es"},
+				{URL: "https://www.google-analytics.com/g/collect?v=2", Host: "www.google-analytics.com"},
+				{URL: "https://www.google-analytics.com/g/collect?v=2&again", Host: "www.google-analytics.com"},
+				{URL: "https://cdn.example-fonts.com/font.woff2", Host: "cdn.example-fonts.com"},
+			},
+			Cookies: []gdpr.Cookie{
+				{Name: "_ga", Domain: ".avis.es"},
+				{Name: "OptanonConsent", Domain: ".avis.es"},
+				{Name: "session", Domain: "www.avis.es"},
+			},
+		},
+		{
+			Phase:    gdpr.PhaseAfterReject,
+			Requests: []gdpr.Request{{URL: "https://connect.facebook.net/en_US/fbevents.js", Host: "connect.facebook.net"}},
+			Cookies:  []gdpr.Cookie{{Name: "id", Domain: ".doubleclick.net"}},
+		},
+	}
+
+	issues := gdpr.Audit("https://www.avis.es/", observaciones, gdpr.DefaultTrackers)
+	require.Equal(t, map[string]int{
+		"tracker-" + gdpr.PhaseBeforeConsent:     1,
+		"third-party-" + gdpr.PhaseBeforeConsent: 1,
+		"cookie-" + gdpr.PhaseBeforeConsent:      1,
+		"tracker-" + gdpr.PhaseAfterReject:       1,
+		"cookie-" + gdpr.PhaseAfterReject:        1,
+	}, reglasGDPR(issues))
+	require.Len(t, lint.Errors(issues), 4, "❌ Solo las peticiones a terceros no rastreadores son avisos")
+
+	require.Equal(t, "avis.es", gdpr.Site("static.avis.es"))
+	require.Equal(t, "bbc.co.uk", gdpr.Site("www.bbc.co.uk"))
+	require.Equal(t, "127.0.0.1", gdpr.Site("127.0.0.1:8080"))
+
+	t.Setenv("GDPR_TRACKERS", gdprTrackersFile)
+	trackers, err := gdpr.TrackersFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, "localhost", trackers.Tracker("localhost"))
+	require.True(t, trackers.NonEssential(gdpr.Cookie{Name: "frt_tracking", Domain: "127.0.0.1"}))
+	require.False(t, trackers.NonEssential(gdpr.Cookie{Name: "frt_session", Domain: "localhost"}), "❌ Las cookies esenciales se permiten")
+}
+
+func TestGDPRLocalPage(t *testing.T) {
+	tracker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/gif")
+	}))
+	defer tracker.Close()
+	// El rastreador se sirve como "localhost" para que sea un sitio distinto de 127.0.0.1
+	trackerURL := strings.Replace(tracker.URL, "127.0.0.1", "localhost", 1)
+
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><body>
+			<img src="%s/pixel.gif">
+			<script>
+				if (!document.cookie.includes('CookieConsent=')) document.cookie = 'frt_tracking=1; path=/';
+				document.cookie = 'frt_session=1; path=/';
+			</script>
+			<div id="CybotCookiebotDialog"><button id="CybotCookiebotDialogBodyButtonDecline"
+				onclick="document.cookie = 'CookieConsent=reject; path=/'; document.getElementById('CybotCookiebotDialog').remove()">Rechazar</button></div>
+		</body></html>`, trackerURL)
+	}))
+	defer site.Close()
+
+	pw, err := playwright.Run()
+	require.NoError(t, err)
+	defer pw.Stop()
+	browser, err := pw.Chromium.Launch()
+	require.NoError(t, err)
+	defer browser.Close()
+
+	t.Setenv("GDPR_TRACKERS", gdprTrackersFile)
+	check := gdpr.NewCheck()
+	check.Trackers, err = gdpr.TrackersFromEnv()
+	require.NoError(t, err)
+	check.Settle = 200 * time.Millisecond
+
+	report, err := check.RunPlaywright(browser, site.URL, playwright.BrowserNewContextOptions{})
+	require.NoError(t, err)
+	require.True(t, report.Consent.Shown(), "❌ No se ha detectado el banner")
+
+	reglas := reglasGDPR(report.Issues)
+	require.Equal(t, 1, reglas["tracker-"+gdpr.PhaseBeforeConsent], "❌ El rastreador cargado antes del consentimiento debe señalarse")
+	require.Equal(t, 1, reglas["cookie-"+gdpr.PhaseBeforeConsent], "❌ Solo la cookie no esencial debe señalarse")
+	require.Equal(t, 1, reglas["tracker-"+gdpr.PhaseAfterReject], "❌ El rastreador cargado tras rechazar debe señalarse")
+	require.Zero(t, reglas["cookie-"+gdpr.PhaseAfterReject], "❌ La cookie de antes del consentimiento no debe señalarse otra vez tras rechazar")
+}
+
+func TestAvisGDPRConsent(t *testing.T) {
+	pw, err := playwright.Run()
+	require.NoError(t, err)
+	defer pw.Stop()
+	browser, err := pw.Chromium.Launch()
+	require.NoError(t, err)
+	defer browser.Close()
+
+	check := gdpr.NewCheck()
+	check.Trackers, err = gdpr.TrackersFromEnv()
+	require.NoError(t, err)
+
+	logger.Printf("🍪 Comprobando el consentimiento de cookies en %s", urlAvis)
+	report, err := check.RunPlaywright(browser, urlAvis, emulation.Spain().PlaywrightOptions())
+	require.NoError(t, err)
+	logger.Printf("🍪 Banner detectado: %q", report.Consent.Provider)
+	for _, observation := range report.Observations {
+		logger.Printf("📡 %s: %d peticiones, %d cookies", observation.Phase, len(observation.Requests), len(observation.Cookies))
+	}
+	for _, issue := range report.Issues {
+		logger.Printf("⚠️ %s", issue)
+	}
+
+	require.True(t, report.Consent.Shown(), "❌ avis.es debe mostrar un banner de consentimiento")
+	require.Empty(t, lint.Errors(report.Issues), "❌ Se rastrea al visitante sin su consentimiento")
+}
@@ -3,7 +3,13 @@
 package e2e
 
 import (
+	"GoLang_FRT_E2E_Tests/pkg/baselines"
+	"GoLang_FRT_E2E_Tests/pkg/links"
+	"GoLang_FRT_E2E_Tests/pkg/lint"
 	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/snapshot"
+	"context"
+	"errors"
 	"testing"
 	"log"
 	"os"
@@ -13,9 +19,8 @@ import (
 )
 
 const (
-	expectedTitle         = "Free Range Testers"
-	expectedSectionsCount = 16
-	expectedLinksCount    = 63
+	expectedTitle = "Free Range Testers"
+	baselinesRoot = "testdata/baselines"
 )
 
 var logger *log.Logger
@@ -62,7 +67,7 @@ func verificarSecciones(page *pages.HomePage, t *testing.T) {
 		return
 	}else{
 		logger.Printf("📊 Número de secciones encontradas: %d", len(secciones))
-		if assert.Len(t, secciones, expectedSectionsCount, "❌ Número de secciones no coincide") {
+		if assert.NotEmpty(t, secciones, "❌ No se han encontrado secciones") {
 			logger.Printf("✅ Test de secciones completado en %.2f", time.Since(startTime).Seconds())
 		}
 		return
@@ -77,7 +82,7 @@ func verificarEnlaces(page *pages.HomePage, t *testing.T) {
 		t.Errorf("❌ Error obteniendo enlaces: %v", err)
 		return
 	}else{
-		if assert.Len(t, enlaces, expectedLinksCount, "❌ Número de enlaces no coincide: %d", len(enlaces)) {
+		if assert.NotEmpty(t, enlaces, "❌ No se han encontrado enlaces") {
 			logger.Printf("🔗 Número de enlaces encontrados: %d", len(enlaces))
 			for i, enlace := range enlaces {
 				logger.Printf("  🌐 Enlace %d: %s", i+1, enlace)
@@ -87,9 +92,80 @@ func verificarEnlaces(page *pages.HomePage, t *testing.T) {
 	}
 }
 
+func verificarSnapshot(page *pages.HomePage, t *testing.T) {
+	startTime := time.Now()
+	logger.Printf("🚀 Iniciando test de instantánea DOM")
+	actual, err := page.Snapshot()
+	if err != nil {
+		t.Errorf("❌ Error obteniendo la instantánea: %v", err)
+		return
+	}
+	logger.Printf("📊 Secciones: %d, enlaces: %d", len(actual.Sections), len(actual.Links))
+	diff, err := snapshot.NewStore(baselines.Dir(baselinesRoot, baselines.Env(), baselines.KindSnapshot)).Assert("home", actual)
+	var missing *snapshot.MissingGoldenError
+	if errors.As(err, &missing) {
+		t.Skipf("⚠️ No hay golden para %s: grábalo con UPDATE_BASELINES=1 o apruébalo con go run ./cmd/baselines approve (%s)", missing.Name, missing.Actual)
+	}
+	if err != nil {
+		if diff != nil {
+			logger.Printf("📝 Diferencias estructurales:\n%s", diff)
+		}
+		t.Errorf("❌ Error la estructura de la página ha cambiado: %v", err)
+		return
+	}
+	logger.Printf("✅ Test de instantánea DOM completado en %.2f", time.Since(startTime).Seconds())
+}
+
+func verificarEnlacesRotos(page *pages.HomePage, checker *links.Checker, t *testing.T) {
+	startTime := time.Now()
+	logger.Printf("🚀 Iniciando test de enlaces rotos")
+	report, err := page.CheckLinks(context.Background(), checker)
+	if err != nil {
+		t.Errorf("❌ Error verificando enlaces: %v", err)
+		return
+	}
+	logger.Printf("🔗 Enlaces verificados: %d, omitidos: %d", len(report.Results), len(report.Skipped))
+	for _, res := range report.Redirected() {
+		logger.Printf("  ↪️ Redirección: %s via %v", res.Link.Resolved, res.Redirects)
+	}
+	for _, res := range report.SlowResults() {
+		logger.Printf("  🐢 Enlace lento: %s", res)
+	}
+	broken := report.Broken()
+	for _, res := range broken {
+		logger.Printf("  💔 Enlace roto: %s", res)
+	}
+	if assert.Empty(t, broken, "❌ Se han encontrado enlaces rotos en %s", report.Source) {
+		logger.Printf("✅ Test de enlaces rotos completado en %.2f", time.Since(startTime).Seconds())
+	}
+}
+
+func verificarLint(page *pages.HomePage, t *testing.T) {
+	startTime := time.Now()
+	logger.Printf("🚀 Iniciando test de accesibilidad estática")
+	issues, err := page.Lint()
+	if err != nil {
+		t.Errorf("❌ Error ejecutando el lint: %v", err)
+		return
+	}
+	for _, issue := range issues {
+		logger.Printf("  ⚠️ %s", issue)
+	}
+	if assert.Empty(t, lint.Errors(issues), "❌ El lint ha encontrado errores de accesibilidad") {
+		logger.Printf("✅ Test de accesibilidad estática completado en %.2f", time.Since(startTime).Seconds())
+	}
+}
+
 func TestHomePage(t *testing.T) {
 	page := pages.NewHomePage()
+	client := clienteGrabado(t, "home_page")
+	page.SetClient(client)
+	checker := links.NewChecker()
+	checker.SetClient(client)
 	t.Run("should have correct title", func(t *testing.T) {verificarTitulo(page, t)})
-	t.Run("should have correct number of sections", func(t *testing.T) {verificarSecciones(page, t)})
-	t.Run("should have correct number of links", func(t *testing.T) {verificarEnlaces(page, t)})
+	t.Run("should have sections", func(t *testing.T) {verificarSecciones(page, t)})
+	t.Run("should have links", func(t *testing.T) {verificarEnlaces(page, t)})
+	t.Run("should match DOM snapshot", func(t *testing.T) {verificarSnapshot(page, t)})
+	t.Run("should not have broken links", func(t *testing.T) {verificarEnlacesRotos(page, checker, t)})
+	t.Run("should pass static accessibility lint", func(t *testing.T) {verificarLint(page, t)})
 }
\ No newline at end of file
@@ -0,0 +1,148 @@
+// tests/e2e/links_test.go
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/links"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const linksFixtureHTML = `<!DOCTYPE html>
+<html lang="es">
+<head><link rel="stylesheet" href="/estilos.css"></head>
+<body>
+	<a href="/ok">OK</a>
+	<a href="/redireccion">Redirección</a>
+	<a href="/roto">Roto</a>
+	<a href="/lento">Lento</a>
+	<a href="/solo-get">Solo GET</a>
+	<a href="#contacto">Ancla</a>
+	<a href="mailto:hola@example.com">Email</a>
+	<a href="javascript:void(0)">JS</a>
+</body>
+</html>`
+
+func TestClassifyLinks(t *testing.T) {
+	base, err := url.Parse("https://www.freerangetesters.com/blog/")
+	require.NoError(t, err)
+
+	cases := map[string]links.Kind{
+		"/cursos":                        links.KindInternal,
+		"https://freerangetesters.com/x": links.KindInternal,
+		"https://github.com/":            links.KindExternal,
+		"img/logo.png":                   links.KindAsset,
+		"#top":                           links.KindAnchor,
+		"mailto:hola@example.com":        links.KindContact,
+		"tel:+34600000000":               links.KindContact,
+		"javascript:void(0)":             links.KindJavaScript,
+	}
+	for raw, kind := range cases {
+		assert.Equal(t, kind, links.Classify(base, raw).Kind, "❌ Clasificación incorrecta para %s", raw)
+	}
+	assert.Equal(t, "https://www.freerangetesters.com/blog/img/logo.png", links.Classify(base, "img/logo.png").Resolved)
+}
+
+func TestCheckLinks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(linksFixtureHTML))
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("/estilos.css", func(w http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("/redireccion", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/lento", func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+	})
+	mux.HandleFunc("/solo-get", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL + "/"
+
+	checker := links.NewChecker()
+	checker.PerHostDelay = 0
+	checker.SlowThreshold = 100 * time.Millisecond
+
+	report, err := page.CheckLinks(context.Background(), checker)
+	require.NoError(t, err)
+
+	assert.Len(t, report.Results, 6, "❌ Número de enlaces verificados no coincide")
+	assert.Len(t, report.Skipped, 3, "❌ Ancla, mailto y javascript no deben verificarse")
+
+	broken := report.Broken()
+	require.Len(t, broken, 1)
+	assert.Equal(t, http.StatusNotFound, broken[0].StatusCode)
+
+	redirected := report.Redirected()
+	require.Len(t, redirected, 1)
+	assert.Equal(t, []string{server.URL + "/redireccion"}, redirected[0].Redirects)
+
+	slow := report.SlowResults()
+	require.Len(t, slow, 1)
+	assert.Equal(t, server.URL+"/lento", slow[0].Link.Resolved)
+
+	for _, res := range report.Results {
+		if res.Link.Resolved == server.URL+"/solo-get" {
+			assert.Equal(t, http.MethodGet, res.Method, "❌ Debe reintentar con GET si HEAD no está permitido")
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		}
+	}
+}
+
+func TestCheckLinksForbidden(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/head-prohibido", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+	mux.HandleFunc("/prohibido", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Un Checker creado como literal debe funcionar sin NewChecker
+	checker := &links.Checker{PerHostDelay: time.Millisecond}
+	report, err := checker.Check(context.Background(), server.URL+"/", []string{"/head-prohibido", "/prohibido"})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+
+	resultados := make(map[string]links.Result)
+	for _, res := range report.Results {
+		resultados[res.Link.Resolved] = res
+	}
+
+	headProhibido := resultados[server.URL+"/head-prohibido"]
+	assert.Equal(t, http.MethodGet, headProhibido.Method, "❌ Debe reintentar con GET si HEAD devuelve 403")
+	assert.False(t, headProhibido.Broken())
+
+	prohibido := resultados[server.URL+"/prohibido"]
+	assert.Equal(t, http.MethodHead, prohibido.Method, "❌ Si GET también falla se informa de la respuesta a HEAD")
+	assert.Equal(t, http.StatusForbidden, prohibido.StatusCode)
+}
@@ -0,0 +1,59 @@
+// tests/e2e/lint_test.go
+
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintFixtureHTML = `<!DOCTYPE html>
+<html>
+<head><title>Fixture</title></head>
+<body>
+	<h2 id="intro">Intro</h2>
+	<h4 id="intro">Detalle</h4>
+	<a href="/vacio"></a>
+	<a href="/icono"><img src="icono.png" alt="Inicio"></a>
+	<img src="sin-alt.png">
+	<img src="decorativa.png" alt="">
+	<label for="email">Email</label>
+	<input id="email" type="email">
+	<input id="nombre" type="text" placeholder="Nombre">
+	<label>Comentario <textarea></textarea></label>
+	<input type="hidden" name="token">
+</body>
+</html>`
+
+func TestStaticLint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(lintFixtureHTML))
+	}))
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+
+	issues, err := page.Lint()
+	require.NoError(t, err)
+
+	rules := make(map[string]int)
+	for _, issue := range issues {
+		rules[issue.Rule]++
+	}
+
+	assert.Equal(t, 1, rules["html-lang"], "❌ Debe detectar la falta de lang")
+	assert.Equal(t, 2, rules["heading-order"], "❌ Debe detectar h2 inicial y el salto a h4")
+	assert.Equal(t, 1, rules["duplicate-id"], "❌ Debe detectar el id duplicado")
+	assert.Equal(t, 1, rules["empty-link"], "❌ Debe detectar solo el enlace vacío")
+	assert.Equal(t, 1, rules["img-alt"], "❌ Debe detectar solo la imagen sin alt")
+	assert.Equal(t, 1, rules["form-label"], "❌ Debe detectar solo el campo sin etiqueta")
+	assert.Len(t, lint.Errors(issues), 5)
+}
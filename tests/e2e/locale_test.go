@@ -0,0 +1,76 @@
+// tests/e2e/locale_test.go
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleSessionOptions(t *testing.T) {
+	session := emulation.Spain()
+	assert.Equal(t, "es-ES, Europe/Madrid", session.Name())
+
+	opts := session.PlaywrightOptions()
+	assert.Equal(t, "es-ES", *opts.Locale)
+	assert.Equal(t, "Europe/Madrid", *opts.TimezoneId)
+	assert.Equal(t, "es-ES,es;q=0.9", opts.ExtraHttpHeaders["Accept-Language"])
+	require.NotNil(t, opts.Geolocation)
+	assert.Equal(t, emulation.MadridBarajas.Latitude, opts.Geolocation.Latitude)
+	assert.Equal(t, []string{"geolocation"}, opts.Permissions, "❌ El permiso de geolocalización debe concederse de antemano")
+}
+
+func TestLocaleSessionChromedp(t *testing.T) {
+	var acceptLanguage string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptLanguage = r.Header.Get("Accept-Language")
+		w.Write([]byte(`<html><body>ok</body></html>`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	session := emulation.Spain()
+	var language, timezone, formatted string
+	var position []float64
+	err := chromedp.Run(ctx,
+		session.ChromedpActions(),
+		chromedp.Navigate(server.URL),
+		chromedp.Evaluate(`navigator.language`, &language),
+		chromedp.Evaluate(`Intl.DateTimeFormat().resolvedOptions().timeZone`, &timezone),
+		chromedp.Evaluate(`new Date(Date.UTC(2025, 0, 15, 12)).toLocaleString()`, &formatted),
+		chromedp.Evaluate(`new Promise((resolve, reject) => navigator.geolocation.getCurrentPosition(
+			p => resolve([p.coords.latitude, p.coords.longitude]), reject))`, &position,
+			func(p *runtime.EvaluateParams) *runtime.EvaluateParams { return p.WithAwaitPromise(true) }),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "es-ES,es;q=0.9", acceptLanguage)
+	assert.Equal(t, "es-ES", language)
+	assert.Equal(t, "Europe/Madrid", timezone)
+	assert.Equal(t, "15/1/2025, 13:00:00", formatted, "❌ La fecha debe mostrarse en formato y hora de Madrid")
+	assert.Equal(t, []float64{emulation.MadridBarajas.Latitude, emulation.MadridBarajas.Longitude}, position)
+}
+
+func TestAvisPageSpain(t *testing.T) {
+	avisPage := pages.NewAvisPageWithSession(emulation.Spain())
+	defer avisPage.Close()
+
+	t.Run("should search for a vehicle with Spanish locale and timezone", func(t *testing.T) {
+		verificarBusquedaAvis(avisPage, t)
+	})
+}
@@ -0,0 +1,89 @@
+// tests/e2e/network_test.go
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/chromedp/chromedp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sesionRedLenta devuelve la sesión de red limitada, que se puede cambiar con NETWORK y CPU_SLOWDOWN
+func sesionRedLenta(t *testing.T) emulation.Session {
+	session, err := emulation.ThrottlingFromEnv(emulation.Session{
+		Network:     &emulation.NetworkSlow3G,
+		CPUSlowdown: emulation.CPUMidTierMobile,
+	})
+	require.NoError(t, err)
+	return session
+}
+
+func TestNetworkProfiles(t *testing.T) {
+	t.Setenv("NETWORK", "fast-3g")
+	t.Setenv("CPU_SLOWDOWN", "6")
+	session, err := emulation.ThrottlingFromEnv(emulation.Session{})
+	require.NoError(t, err)
+	assert.Equal(t, "Fast 3G, CPU 6x", session.Name())
+	assert.Len(t, session.ChromedpActions(), 2)
+
+	t.Setenv("NETWORK", "dial-up")
+	_, err = emulation.ThrottlingFromEnv(emulation.Session{})
+	assert.Error(t, err)
+
+	offline := emulation.Session{Network: &emulation.NetworkOffline}
+	assert.True(t, *offline.PlaywrightOptions().Offline)
+	assert.False(t, offline.IsZero())
+}
+
+func TestNetworkThrottlingChromedp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`<html><body>ok</body></html>`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	latency := 500 * time.Millisecond
+	session := emulation.Session{Network: ptr(emulation.CustomNetwork("Latencia", latency, 0, 0))}
+	require.NoError(t, chromedp.Run(ctx, session.ChromedpActions()))
+
+	start := time.Now()
+	require.NoError(t, chromedp.Run(ctx, chromedp.Navigate(server.URL)))
+	assert.GreaterOrEqual(t, time.Since(start), latency, "❌ La navegación debe sufrir la latencia emulada")
+
+	offline := emulation.Session{Network: &emulation.NetworkOffline}
+	require.NoError(t, chromedp.Run(ctx, offline.ChromedpActions()))
+	assert.Error(t, chromedp.Run(ctx, chromedp.Navigate(server.URL)), "❌ Sin red la navegación debe fallar")
+}
+
+func TestSandboxPageSlowNetwork(t *testing.T) {
+	page := pages.NewSandboxPage()
+	page.Session = sesionRedLenta(t)
+	t.Run("should click dynamic button on a slow network", func(t *testing.T) { verificarBotonDinamico(page, t) })
+	t.Run("should insert text in textbox on a slow network", func(t *testing.T) { verificarTextbox(page, t) })
+}
+
+func TestAvisPageSlowNetwork(t *testing.T) {
+	avisPage := pages.NewAvisPageWithSession(sesionRedLenta(t))
+	defer avisPage.Close()
+
+	t.Run("should search for a vehicle on a slow network", func(t *testing.T) {
+		verificarBusquedaAvis(avisPage, t)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
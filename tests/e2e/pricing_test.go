@@ -0,0 +1,82 @@
+// tests/e2e/pricing_test.go
+package e2e
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/pricing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingMatrix(t *testing.T) {
+	matrix, err := pricing.LoadMatrix("testdata/pricing/matrix.yaml")
+	require.NoError(t, err)
+
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	now := time.Date(2030, 3, 5, 12, 0, 0, 0, madrid)
+	scenarios, err := matrix.Scenarios(now)
+	require.NoError(t, err)
+
+	// 2030-03-01 ya ha pasado; quedan 03-08, 03-15 y la fecha relativa +2d (03-07), por 2 rutas y 2 duraciones
+	require.Len(t, scenarios, 3*2*2)
+	first := scenarios[0]
+	require.Equal(t, time.Date(2030, 3, 8, 9, 0, 0, 0, madrid), first.PickupTime)
+	require.Equal(t, time.Date(2030, 3, 11, 18, 0, 0, 0, madrid), first.ReturnTime)
+	require.Equal(t, "madrid-barajas-adolfo-suárez-t1-y-t4-esp|2030-03-08|3d", first.ID)
+	require.Contains(t, scenarios[2].ID, ">barcelona-el-prat-t1-y-t2-esp|")
+
+	_, err = pricing.ParseMatrix(strings.NewReader("locations: []\nlengths: [3]\n"))
+	require.Error(t, err, "❌ Una matriz sin ubicaciones debe rechazarse")
+	_, err = pricing.ParseMatrix(strings.NewReader("unknown: true\n"))
+	require.Error(t, err, "❌ Los campos desconocidos deben rechazarse")
+}
+
+func TestPricingStoreAndReport(t *testing.T) {
+	store := pricing.NewStore(filepath.Join(t.TempDir(), "prices.jsonl"))
+	registro := func(run, escenario, modelo string, ahora, despues float64) pricing.Record {
+		return pricing.Record{RunID: run, Scenario: escenario, Model: modelo, ACRISS: "CDMR", PayNow: ahora, PayLater: despues, Currency: "EUR", Available: true}
+	}
+
+	require.NoError(t, store.Append([]pricing.Record{
+		registro("r1", "mad|2030-03-08|3d", "Volkswagen Golf", 100, 110),
+		registro("r1", "mad|2030-03-08|3d", "Fiat 500", 80, 90),
+		registro("r1", "bcn|2030-03-08|3d", "Seat Ibiza", 70, 75),
+	}))
+	require.NoError(t, store.Append([]pricing.Record{
+		registro("r2", "mad|2030-03-08|3d", "Volkswagen Golf", 105, 115),
+		registro("r2", "mad|2030-03-08|3d", "Toyota Yaris", 0, 0),
+		{RunID: "r2", Scenario: "bcn|2030-03-08|3d", Error: "timeout"},
+	}))
+	require.NoError(t, store.Append([]pricing.Record{
+		registro("r3", "mad|2030-03-08|3d", "Volkswagen Golf", 150, 140),
+		registro("r3", "mad|2030-03-08|3d", "Toyota Yaris", 60, 65),
+	}))
+
+	runs, err := store.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+
+	// r2 frente a r1: el Fiat desaparece en un escenario buscado; el de Barcelona falló y no cuenta como desaparecido
+	report := pricing.Compare(&runs[0], &runs[1], pricing.DefaultOptions())
+	require.Len(t, report.Changes, 1)
+	require.InDelta(t, 0.05, report.Changes[0].Delta(), 0.001)
+	kinds := make(map[string]int)
+	for _, anomaly := range report.Anomalies {
+		kinds[anomaly.Kind]++
+	}
+	require.Equal(t, map[string]int{pricing.AnomalyDisappeared: 1, pricing.AnomalyMissing: 1, pricing.AnomalySearchError: 1}, kinds)
+
+	// r3 frente a r2: salto de precio y precio de pagar ahora por encima del de pagar después
+	previous, latest, err := store.LastTwo()
+	require.NoError(t, err)
+	report = pricing.Compare(previous, latest, pricing.DefaultOptions())
+	kinds = make(map[string]int)
+	for _, anomaly := range report.Anomalies {
+		kinds[anomaly.Kind]++
+	}
+	require.Equal(t, map[string]int{pricing.AnomalyPriceJump: 1, pricing.AnomalyInverted: 1}, kinds)
+	logger.Printf("📈 Informe de precios:\n%s", report)
+}
@@ -3,7 +3,11 @@
 package e2e
 
 import (
+    "GoLang_FRT_E2E_Tests/pkg/baselines"
     "GoLang_FRT_E2E_Tests/pkg/pages"
+    "GoLang_FRT_E2E_Tests/pkg/visual"
+    "errors"
+    "path/filepath"
     "testing"
     "time"
     
@@ -15,6 +19,8 @@ const (
 	expectedTitleSandbox  = "Automation Sandbox"
 	expectedSectionsCountSandbox = 16
 	expectedLinksCountSandbox    = 5
+	dynamicTableSelectorSandbox  = "#root > div > div:nth-child(7) > div > table"
+	visualReportDir              = "../../reports/visual"
 )
 func verificarTituloSandbox(page *pages.SandboxPage, t *testing.T) {
     startTime := time.Now()
@@ -54,7 +60,7 @@ func verificarEnlacesSandbox(page *pages.SandboxPage, t *testing.T) {
 
 func verificarBotonDinamico(page *pages.SandboxPage, t *testing.T) {
     startTime := time.Now()
-    logger.Printf("🚀 Iniciando test de botón dinámico en Sandbox")
+    logger.Printf("🚀 Iniciando test de botón dinámico en Sandbox%s", variante(page.Session))
     boton, err := page.ClickDynamicButton()
     if err != nil {
         t.Errorf("❌ Error obteniendo el botón dinámico: %v", err)
@@ -68,7 +74,7 @@ func verificarBotonDinamico(page *pages.SandboxPage, t *testing.T) {
 
 func verificarTextbox(page *pages.SandboxPage, t *testing.T) {
     startTime := time.Now()
-    logger.Printf("🚀 Iniciando test de textbox en Sandbox")
+    logger.Printf("🚀 Iniciando test de textbox en Sandbox%s", variante(page.Session))
     textbox, err := page.InsertTextInTextbox("Texto de prueba")
     if err != nil {
         t.Errorf("❌ Error obteniendo el textbox: %v", err)
@@ -160,8 +166,38 @@ func verificarTablas(page *pages.SandboxPage, t *testing.T) {
     }
 }
 
+func verificarRegresionVisual(page *pages.SandboxPage, t *testing.T) {
+    startTime := time.Now()
+    logger.Printf("🚀 Iniciando test de regresión visual en Sandbox")
+    screenshot, err := page.CaptureScreenshot("", dynamicTableSelectorSandbox)
+    if err != nil {
+        t.Errorf("❌ Error capturando la pantalla: %v", err)
+        return
+    }
+    store := visual.NewStore(baselines.Dir(baselinesRoot, baselines.Env(), baselines.KindVisual), visualReportDir)
+    result, err := store.Assert("sandbox", screenshot, visual.DefaultOptions())
+    var missing *visual.MissingBaselineError
+    if errors.As(err, &missing) {
+        t.Skipf("⚠️ No hay referencia visual para %s: grábala con UPDATE_BASELINES=1 o apruébala con go run ./cmd/baselines approve (%s)", missing.Name, missing.Actual)
+    }
+    var mismatch *visual.MismatchError
+    if errors.As(err, &mismatch) {
+        triptych, _ := filepath.Rel(filepath.Dir(visualReportDir), mismatch.Triptych)
+        logger.Printf("🖼️ Diferencia visual: %s", triptych)
+        t.Errorf("❌ Error la captura no coincide con la referencia: %s", mismatch.Result)
+        return
+    }
+    if err != nil {
+        t.Errorf("❌ Error comparando la captura: %v", err)
+        return
+    }
+    logger.Printf("📝 Resultado de la comparación visual: %s", result)
+    logger.Printf("✅ Test de regresión visual completado en %.2f", time.Since(startTime).Seconds())
+}
+
 func TestSandboxPage(t *testing.T) {
     page := pages.NewSandboxPage()
+    page.SetClient(clienteGrabado(t, "sandbox_page"))
     t.Run("should have correct title", func(t *testing.T){verificarTituloSandbox(page, t)})
     //t.Run("should have correct number of sections", func(t *testing.T){verificarSeccionesSandbox(page, t)})
     t.Run("should have correct number of links", func(t *testing.T){verificarEnlacesSandbox(page, t)})
@@ -172,4 +208,5 @@ func TestSandboxPage(t *testing.T) {
     t.Run("should handle popup", func(t *testing.T){verificarPopup(page, t)})
     t.Run("should interact with shadow DOM", func(t *testing.T){verificarShadowDom(page, t)})
     t.Run("should interact with tables", func(t *testing.T){verificarTablas(page, t)})
+    t.Run("should match visual baseline", func(t *testing.T){verificarRegresionVisual(page, t)})
 }
@@ -0,0 +1,101 @@
+// tests/e2e/sandbox_replica_test.go
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/replica"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sandboxLocal crea un SandboxPage apuntando a una réplica local con las opciones indicadas
+func sandboxLocal(t *testing.T, opts replica.SandboxOptions) *pages.SandboxPage {
+	server := replica.NewSandboxServer(opts)
+	t.Cleanup(server.Close)
+	page := pages.NewSandboxPage()
+	page.URL = server.URL + "/"
+	logger.Printf("🏠 Réplica local del Sandbox en %s", page.URL)
+	return page
+}
+
+func TestSandboxReplicaStatic(t *testing.T) {
+	page := sandboxLocal(t, replica.SandboxOptions{})
+	t.Run("should have correct title", func(t *testing.T) { verificarTituloSandbox(page, t) })
+	t.Run("should have correct number of links", func(t *testing.T) { verificarEnlacesSandbox(page, t) })
+}
+
+func TestSandboxReplica(t *testing.T) {
+	page := sandboxLocal(t, replica.SandboxOptions{RevealDelay: 500 * time.Millisecond})
+
+	// Los mismos helpers que contra el sitio real
+	t.Run("should click dynamic button", func(t *testing.T) { verificarBotonDinamico(page, t) })
+	t.Run("should interact with tables", func(t *testing.T) { verificarTablas(page, t) })
+
+	// Y los valores exactos, que en la réplica son deterministas
+	t.Run("dynamic button reveals hidden element", func(t *testing.T) {
+		texto, err := page.ClickDynamicButton()
+		require.NoError(t, err)
+		assert.Contains(t, texto, "aparezco después de 3 segundos")
+	})
+	t.Run("textbox keeps inserted text", func(t *testing.T) {
+		texto, err := page.InsertTextInTextbox("Texto de prueba")
+		require.NoError(t, err)
+		assert.Equal(t, "Texto de prueba", texto)
+	})
+	t.Run("checkboxes and radios", func(t *testing.T) {
+		checkbox, radio, err := page.TestCheckboxesAndRadioButtons()
+		require.NoError(t, err)
+		assert.Equal(t, "Pasta 🍝", checkbox)
+		assert.Equal(t, "No", radio, "❌ Solo el último radio pulsado debe quedar seleccionado")
+	})
+	t.Run("dropdowns", func(t *testing.T) {
+		deporte, dia, err := page.ClickDropdowns()
+		require.NoError(t, err)
+		assert.Equal(t, "Fútbol", deporte)
+		assert.Equal(t, "Martes", dia)
+	})
+	t.Run("popup", func(t *testing.T) {
+		texto, err := page.HandlePopup()
+		require.NoError(t, err)
+		assert.Equal(t, "¿Viste? ¡Apareció un Pop-up!", texto)
+	})
+	t.Run("shadow DOM", func(t *testing.T) {
+		contenido, err := page.InteractWithShadowDOM()
+		require.NoError(t, err)
+		assert.Equal(t, "Este es un ejemplo de Shadow DOM", contenido)
+	})
+}
+
+func TestSandboxReplicaFailures(t *testing.T) {
+	t.Run("missing dynamic button", func(t *testing.T) {
+		page := sandboxLocal(t, replica.SandboxOptions{Missing: []string{replica.SandboxDynamicButton}})
+		_, err := page.ClickDynamicButton()
+		var pageError *pages.PageError
+		require.ErrorAs(t, err, &pageError, "❌ Un elemento ausente debe devolver un PageError")
+		logger.Printf("📝 Error esperado: %v", err)
+	})
+
+	t.Run("missing textbox", func(t *testing.T) {
+		page := sandboxLocal(t, replica.SandboxOptions{Missing: []string{replica.SandboxTextbox}})
+		_, err := page.InsertTextInTextbox("Texto de prueba")
+		require.Error(t, err)
+	})
+
+	t.Run("slow rendering within timeout", func(t *testing.T) {
+		page := sandboxLocal(t, replica.SandboxOptions{RenderDelay: 3 * time.Second})
+		texto, err := page.InsertTextInTextbox("Texto de prueba")
+		require.NoError(t, err, "❌ Las esperas deben tolerar un renderizado lento")
+		assert.Equal(t, "Texto de prueba", texto)
+	})
+
+	t.Run("rendering slower than timeout", func(t *testing.T) {
+		page := sandboxLocal(t, replica.SandboxOptions{RenderDelay: 30 * time.Second})
+		_, err := page.InteractWithShadowDOM()
+		require.Error(t, err)
+	})
+}
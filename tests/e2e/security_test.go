@@ -0,0 +1,128 @@
+// tests/e2e/security_test.go
+
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/security"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityFixtureServer(headers map[string]string, cookies ...*http.Cookie) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		for _, cookie := range cookies {
+			http.SetCookie(w, cookie)
+		}
+		w.Write([]byte(`<html lang="es"><head><title>Seguridad</title></head></html>`))
+	}))
+}
+
+func TestSecurityHeadersAudit(t *testing.T) {
+	server := newSecurityFixtureServer(map[string]string{
+		"Content-Security-Policy":   "default-src 'self'; frame-ancestors 'none'",
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		"X-Content-Type-Options":    "nosniff",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+		"Permissions-Policy":        "geolocation=()",
+	}, &http.Cookie{Name: "sesion", Value: "1", Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+	page.SetClient(server.Client())
+
+	result, err := page.Fetch()
+	require.NoError(t, err)
+	require.NotNil(t, result.TLS, "❌ Debe exponer el estado TLS")
+	assert.Equal(t, "nosniff", result.Header.Get("X-Content-Type-Options"))
+	require.Len(t, result.Cookies, 1)
+
+	issues, err := page.AuditSecurity()
+	require.NoError(t, err)
+	assert.Empty(t, issues, "❌ Una respuesta segura no debe generar avisos: %v", issues)
+}
+
+func TestSecurityHeadersAuditFindings(t *testing.T) {
+	server := newSecurityFixtureServer(map[string]string{
+		"Content-Security-Policy":   "script-src 'self' 'unsafe-inline'",
+		"Strict-Transport-Security": "max-age=3600",
+		"Referrer-Policy":           "unsafe-url",
+	},
+		&http.Cookie{Name: "tracking", Value: "1", SameSite: http.SameSiteNoneMode},
+		&http.Cookie{Name: "prefs", Value: "1", Secure: true},
+	)
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+	page.SetClient(server.Client())
+
+	result, err := page.Fetch()
+	require.NoError(t, err)
+
+	// El certificado de httptest caduca en 2084; adelantamos el reloj para forzar el aviso
+	auditor := security.NewAuditor()
+	cert := result.TLS.PeerCertificates[0]
+	auditor.Now = func() time.Time { return cert.NotAfter.Add(-7 * 24 * time.Hour) }
+
+	issues := auditor.Audit(result.URL, result.Header, result.Cookies, result.TLS)
+
+	rules := make(map[string][]string)
+	for _, issue := range issues {
+		rules[issue.Rule] = append(rules[issue.Rule], string(issue.Severity)+" "+issue.Element+" "+issue.Message)
+	}
+
+	assert.Len(t, rules["csp"], 1, "❌ Debe avisar de 'unsafe-inline'")
+	assert.Len(t, rules["framing"], 1)
+	assert.Len(t, rules["content-type-options"], 1)
+	assert.Len(t, rules["referrer-policy"], 1)
+	assert.Len(t, rules["permissions-policy"], 1)
+	assert.Contains(t, strings.Join(rules["hsts"], "|"), "inferior")
+	assert.Contains(t, strings.Join(rules["tls-certificate"], "|"), "caduca")
+	var erroresTracking []string
+	for _, regla := range rules["cookie"] {
+		if strings.HasPrefix(regla, "error cookie tracking ") {
+			erroresTracking = append(erroresTracking, regla)
+		}
+	}
+	assert.Equal(t, []string{"error cookie tracking Falta el flag Secure"}, erroresTracking, "❌ SameSite=None sin Secure es un único defecto")
+	assert.Contains(t, strings.Join(rules["cookie"], "|"), "cookie prefs Falta el atributo SameSite")
+	assert.NotEmpty(t, lint.Errors(issues))
+}
+
+func TestSecurityAuditErrorPage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`<html lang="es"><head><title>Mantenimiento</title></head></html>`))
+	}))
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+	page.SetClient(server.Client())
+
+	// Fetch devuelve la respuesta de error para que se pueda auditar
+	result, err := page.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+	issues, err := page.AuditSecurity()
+	require.NoError(t, err)
+	assert.NotEmpty(t, issues, "❌ La página de error también debe auditarse")
+
+	// Los métodos que leen el contenido siguen fallando con una página de error
+	_, err = page.GetTitle()
+	var pageError *pages.PageError
+	require.ErrorAs(t, err, &pageError)
+}
@@ -0,0 +1,72 @@
+// tests/e2e/seo_test.go
+
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/lint"
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const seoFixtureHTML = `<!DOCTYPE html>
+<html lang="es">
+<head>
+	<title>Free Range Testers</title>
+	<meta name="description" content="Corta">
+	<meta name="description" content="Otra descripción duplicada">
+	<meta name="robots" content="noindex, follow">
+	<link rel="canonical" href="/relativa">
+	<meta property="og:title" content="Free Range Testers">
+	<meta property="og:type" content="website">
+	<meta property="og:url" content="https://www.freerangetesters.com">
+	<meta name="twitter:card" content="tarjeta">
+	<link rel="alternate" hreflang="es" href="https://www.freerangetesters.com/">
+	<link rel="alternate" hreflang="es" href="https://www.freerangetesters.com/es">
+	<link rel="alternate" hreflang="english" href="/en">
+	<script type="application/ld+json">{"@context":"https://schema.org","@type":"Organization","name":"FRT"}</script>
+	<script type="application/ld+json">{"@type": </script>
+</head>
+<body></body>
+</html>`
+
+func TestSEOMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(seoFixtureHTML))
+	}))
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+
+	meta, issues, err := page.CheckSEO()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Free Range Testers"}, meta.Title)
+	assert.True(t, meta.NoIndex())
+	assert.Len(t, meta.Alternates, 3)
+	require.Len(t, meta.StructuredData, 2)
+	assert.Equal(t, []string{"Organization"}, meta.StructuredData[0].Types)
+	assert.Error(t, meta.StructuredData[1].Err)
+
+	messages := make(map[string][]string)
+	for _, issue := range issues {
+		messages[issue.Rule] = append(messages[issue.Rule], issue.Message)
+	}
+
+	assert.NotContains(t, messages, "seo-title")
+	assert.Contains(t, strings.Join(messages["seo-description"], "|"), "Meta description duplicada")
+	assert.Contains(t, strings.Join(messages["seo-canonical"], "|"), "no es absoluta")
+	assert.Contains(t, strings.Join(messages["seo-robots"], "|"), "noindex")
+	assert.Equal(t, []string{"Falta la propiedad OpenGraph"}, messages["seo-opengraph"], "❌ Solo falta og:image")
+	assert.Contains(t, strings.Join(messages["seo-twitter"], "|"), "Twitter card desconocido")
+	assert.Len(t, messages["seo-hreflang"], 3, "❌ Debe detectar duplicado, código inválido y URL relativa")
+	assert.Len(t, messages["seo-jsonld"], 1)
+	assert.NotEmpty(t, lint.Errors(issues))
+}
@@ -0,0 +1,92 @@
+// tests/e2e/snapshot_test.go
+
+package e2e
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/snapshot"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const snapshotFixtureHTML = `<!DOCTYPE html>
+<html lang="es"><head><title>Free Range Testers</title></head>
+<body>
+	<div id="page_section_1"><h2>Cursos</h2><p>Aprende   testing</p></div>
+	<div id="page_section_2"><h2>Blog</h2><p>Artículos</p></div>
+	<a href="/cursos">Cursos</a>
+	<a href="/blog">Blog</a>
+</body></html>`
+
+const snapshotChangedHTML = `<!DOCTYPE html>
+<html lang="es"><head><title>Free Range Testers</title></head>
+<body>
+	<div id="page_section_1"><h2>Cursos</h2><p>Aprende testing y automatización</p></div>
+	<div id="page_section_3"><h2>Comunidad</h2></div>
+	<a href="/cursos">Cursos</a>
+	<a href="/comunidad">Comunidad</a>
+</body></html>`
+
+func TestDOMSnapshot(t *testing.T) {
+	html := snapshotFixtureHTML
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	page := pages.NewHomePage()
+	page.URL = server.URL
+
+	store := snapshot.NewStore(t.TempDir())
+	store.Update = false
+
+	// Sin golden la prueba falla y la instantánea queda pendiente de aprobar
+	actual, err := page.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, "Cursos Aprende testing", actual.Sections[0].Text, "❌ El texto debe normalizarse")
+	_, err = store.Assert("home", actual)
+	var missing *snapshot.MissingGoldenError
+	require.True(t, errors.As(err, &missing), "❌ Debe devolver MissingGoldenError: %v", err)
+	assert.FileExists(t, missing.Actual)
+	assert.NoFileExists(t, store.GoldenPath("home"))
+
+	// El modo actualización crea el golden
+	store.Update = true
+	_, err = store.Assert("home", actual)
+	require.NoError(t, err)
+	assert.FileExists(t, store.GoldenPath("home"))
+	assert.NoFileExists(t, missing.Actual)
+	store.Update = false
+
+	// Un cambio de contenido muestra un diff estructural legible
+	html = snapshotChangedHTML
+	actual, err = page.Snapshot()
+	require.NoError(t, err)
+	diff, err := store.Assert("home", actual)
+
+	var mismatch *snapshot.MismatchError
+	require.True(t, errors.As(err, &mismatch), "❌ Debe devolver MismatchError: %v", err)
+	require.Len(t, diff.AddedSections, 1)
+	assert.Equal(t, "page_section_3", diff.AddedSections[0].ID)
+	require.Len(t, diff.RemovedSections, 1)
+	assert.Equal(t, "page_section_2", diff.RemovedSections[0].ID)
+	require.Len(t, diff.ChangedSections, 1)
+	assert.Equal(t, []string{"/comunidad"}, diff.AddedLinks)
+	assert.Equal(t, []string{"/blog"}, diff.RemovedLinks)
+	assert.Contains(t, diff.String(), "+ section page_section_3: \"Comunidad\"")
+	assert.Contains(t, diff.String(), "- link /blog")
+
+	// El modo actualización acepta la nueva estructura
+	store.Update = true
+	_, err = store.Assert("home", actual)
+	require.NoError(t, err)
+	golden, err := store.Load("home")
+	require.NoError(t, err)
+	assert.Equal(t, actual, golden)
+}
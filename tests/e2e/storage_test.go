@@ -0,0 +1,258 @@
+// tests/e2e/storage_test.go
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/consent"
+	"GoLang_FRT_E2E_Tests/pkg/emulation"
+	"GoLang_FRT_E2E_Tests/pkg/storagestate"
+
+	"github.com/chromedp/chromedp"
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	returningCustomerState = "testdata/storage/returning_customer.json"
+	firstTimeVisitorState  = "testdata/storage/first_time_visitor.json"
+)
+
+// storagePage muestra el banner de Cookiebot hasta que se acepta, saluda al cliente guardado
+// en localStorage y muestra el carrito guardado en sessionStorage
+const storagePage = `<html><body>
+<div id="CybotCookiebotDialog">
+	<button id="CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll" onclick="accept()">Permitir todas</button>
+	<button id="CybotCookiebotDialogBodyButtonDecline" onclick="accept()">Rechazar</button>
+</div>
+<p id="greeting"></p>
+<p id="cart"></p>
+<script>
+	function accept() {
+		document.cookie = 'CookieConsent=accept; path=/; max-age=3600';
+		localStorage.setItem('customer', 'Ana');
+		sessionStorage.setItem('cart', 'CDMR');
+		document.getElementById('CybotCookiebotDialog').style.display = 'none';
+	}
+	if (document.cookie.includes('CookieConsent=')) {
+		document.getElementById('CybotCookiebotDialog').style.display = 'none';
+	}
+	const customer = localStorage.getItem('customer');
+	document.getElementById('greeting').innerText = customer ? 'Hola de nuevo, ' + customer : 'Bienvenido';
+	document.getElementById('cart').innerText = sessionStorage.getItem('cart') || 'vacío';
+</script>
+</body></html>`
+
+func storageServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(storagePage))
+	}))
+}
+
+// estadoPrecargado carga un estado de testdata y lo asocia al origen del servidor de pruebas
+func estadoPrecargado(t *testing.T, file, serverURL string) *storagestate.State {
+	state, err := storagestate.Load(file)
+	require.NoError(t, err)
+	for i := range state.Origins {
+		state.Origins[i].Origin = serverURL
+	}
+	return state
+}
+
+// contenidoStorage lee el saludo y el carrito que muestra la página
+func contenidoStorage(t *testing.T, page playwright.Page) (string, string) {
+	greeting, err := page.Locator("#greeting").InnerText()
+	require.NoError(t, err)
+	cart, err := page.Locator("#cart").InnerText()
+	require.NoError(t, err)
+	return greeting, cart
+}
+
+func TestStorageStateFile(t *testing.T) {
+	state, err := storagestate.Load(returningCustomerState)
+	require.NoError(t, err)
+	require.Equal(t, "returning-customer", state.Name)
+	require.False(t, state.IsEmpty())
+
+	cookie, ok := state.Cookie("CookieConsent")
+	require.True(t, ok)
+	require.False(t, cookie.Expired(time.Now()), "❌ Una cookie de sesión no caduca")
+	require.True(t, storagestate.Cookie{Expires: 1}.Expired(time.Now()))
+
+	origin, ok := state.Origin("http://127.0.0.1/")
+	require.True(t, ok)
+	require.Len(t, origin.LocalStorage, 2)
+	require.Equal(t, "CDMR", origin.SessionStorage[0].Value)
+
+	file := filepath.Join(t.TempDir(), "state", "copy.json")
+	require.NoError(t, state.Save(file))
+	loaded, err := storagestate.Load(file)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+
+	empty, err := storagestate.Load(firstTimeVisitorState)
+	require.NoError(t, err)
+	require.True(t, empty.IsEmpty())
+
+	t.Setenv("STORAGE_STATE", "")
+	fromEnv, err := storagestate.FromEnv()
+	require.NoError(t, err)
+	require.Nil(t, fromEnv)
+
+	session := emulation.Session{Storage: state}
+	require.False(t, session.IsZero())
+	require.Equal(t, "returning-customer", session.Name())
+	require.Len(t, session.PlaywrightOptions().StorageState.Cookies, 1)
+}
+
+func TestStorageStateChromedp(t *testing.T) {
+	server := storageServer()
+	defer server.Close()
+	file := filepath.Join(t.TempDir(), "chromedp.json")
+
+	// Primera sesión: se acepta el banner y se guarda el estado
+	ctx, cancel := chromedp.NewContext(context.Background())
+	ctx, cancelTimeout := context.WithTimeout(ctx, 60*time.Second)
+	var result consent.Result
+	state := &storagestate.State{}
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(server.URL),
+		consent.NewManager().Chromedp(consent.Accept(), &result),
+		storagestate.CaptureChromedp(state),
+	)
+	cancelTimeout()
+	cancel()
+	require.NoError(t, err)
+	require.True(t, result.Shown())
+	require.NoError(t, state.Save(file))
+
+	_, ok := state.Cookie("CookieConsent")
+	require.True(t, ok, "❌ No se ha guardado la cookie de consentimiento")
+	origin, ok := state.Origin(server.URL)
+	require.True(t, ok, "❌ No se ha guardado el almacenamiento del origen")
+	require.NotEmpty(t, origin.SessionStorage)
+
+	// Segunda sesión, en un navegador nuevo: el banner ya no aparece
+	restored, err := storagestate.Load(file)
+	require.NoError(t, err)
+	session := emulation.Session{Storage: restored}
+
+	ctx, cancel = chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	manager := &consent.Manager{Providers: consent.DefaultProviders, Timeout: time.Second}
+	var greeting, cart string
+	result = consent.Result{}
+	require.NoError(t, chromedp.Run(ctx,
+		session.ChromedpActions(),
+		chromedp.Navigate(server.URL),
+		manager.Chromedp(consent.Accept(), &result),
+		chromedp.Text("#greeting", &greeting, chromedp.ByQuery),
+		chromedp.Text("#cart", &cart, chromedp.ByQuery),
+	))
+	require.False(t, result.Shown(), "❌ El banner no debería aparecer con el estado restaurado")
+	require.Equal(t, "Hola de nuevo, Ana", greeting)
+	require.Equal(t, "CDMR", cart)
+
+	// El estado se siembra una sola vez: lo que la página borra no reaparece al recargar
+	recaptured := &storagestate.State{}
+	require.NoError(t, chromedp.Run(ctx,
+		chromedp.Evaluate(`localStorage.removeItem('customer')`, nil),
+		chromedp.Reload(),
+		chromedp.Text("#greeting", &greeting, chromedp.ByQuery),
+		storagestate.CaptureChromedp(recaptured),
+	))
+	require.Equal(t, "Bienvenido", greeting, "❌ El localStorage no debe restaurarse en cada documento")
+	origin, ok = recaptured.Origin(server.URL)
+	require.True(t, ok)
+	for _, entry := range origin.LocalStorage {
+		require.NotEqual(t, "customer", entry.Name)
+		require.False(t, strings.HasPrefix(entry.Name, "__"), "❌ La marca de restauración no debe capturarse")
+	}
+}
+
+func TestStorageStatePlaywright(t *testing.T) {
+	server := storageServer()
+	defer server.Close()
+
+	pw, err := playwright.Run()
+	require.NoError(t, err)
+	defer pw.Stop()
+	browser, err := pw.Chromium.Launch()
+	require.NoError(t, err)
+	defer browser.Close()
+
+	// abrir crea un contexto con la sesión indicada y navega a la página de pruebas
+	abrir := func(t *testing.T, session emulation.Session) (playwright.BrowserContext, playwright.Page) {
+		browserContext, err := browser.NewContext(session.PlaywrightOptions())
+		require.NoError(t, err)
+		page, err := browserContext.NewPage()
+		require.NoError(t, err)
+		require.NoError(t, session.ApplyPlaywright(browserContext, page))
+		_, err = page.Goto(server.URL)
+		require.NoError(t, err)
+		return browserContext, page
+	}
+	manager := &consent.Manager{Providers: consent.DefaultProviders, Timeout: time.Second}
+
+	t.Run("accept once and reuse", func(t *testing.T) {
+		browserContext, page := abrir(t, emulation.Session{})
+		result, err := manager.Playwright(page, consent.Accept())
+		require.NoError(t, err)
+		require.True(t, result.Shown())
+
+		state, err := storagestate.CapturePlaywright(browserContext)
+		require.NoError(t, err)
+		browserContext.Close()
+		file := filepath.Join(t.TempDir(), "playwright.json")
+		require.NoError(t, state.Save(file))
+
+		restored, err := storagestate.Load(file)
+		require.NoError(t, err)
+		browserContext, page = abrir(t, emulation.Session{Storage: restored})
+		defer browserContext.Close()
+
+		result, err = manager.Playwright(page, consent.Accept())
+		require.NoError(t, err)
+		require.False(t, result.Shown(), "❌ El banner no debería aparecer con el estado restaurado")
+		greeting, cart := contenidoStorage(t, page)
+		require.Equal(t, "Hola de nuevo, Ana", greeting)
+		require.Equal(t, "CDMR", cart)
+	})
+
+	// El mismo test con distintos estados precargados
+	casos := []struct {
+		file          string
+		bannerVisible bool
+		saludo        string
+		carrito       string
+	}{
+		{returningCustomerState, false, "Hola de nuevo, Ana", "CDMR"},
+		{firstTimeVisitorState, true, "Bienvenido", "vacío"},
+	}
+	for _, caso := range casos {
+		state := estadoPrecargado(t, caso.file, server.URL)
+		t.Run(state.Name, func(t *testing.T) {
+			browserContext, page := abrir(t, emulation.Session{Storage: state})
+			defer browserContext.Close()
+
+			visible, err := page.Locator(consent.Cookiebot.Banner).IsVisible()
+			require.NoError(t, err)
+			require.Equal(t, caso.bannerVisible, visible)
+
+			greeting, cart := contenidoStorage(t, page)
+			require.Equal(t, caso.saludo, greeting, fmt.Sprintf("❌ Saludo inesperado para %s", state.Name))
+			require.Equal(t, caso.carrito, cart)
+		})
+	}
+}
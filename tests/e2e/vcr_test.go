@@ -0,0 +1,214 @@
+// tests/e2e/vcr_test.go
+
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoLang_FRT_E2E_Tests/pkg/pages"
+	"GoLang_FRT_E2E_Tests/pkg/vcr"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cassettesDir = "testdata/cassettes"
+
+// clienteGrabado devuelve un cliente HTTP que pasa por la cassette indicada. Sin VCR_MODE va a la red;
+// con VCR_MODE=record se vuelve a grabar; con VCR_MODE=replay y VCR_STRICT=1 falla cualquier petición
+// no grabada y, si la cassette no existe, el test se omite.
+func clienteGrabado(t *testing.T, nombre string) *http.Client {
+	path := filepath.Join(cassettesDir, nombre+".json")
+	recorder, err := vcr.FromEnv(path)
+	require.NoError(t, err)
+	if recorder.Mode == vcr.ModeReplay && len(recorder.Cassette().Interactions) == 0 {
+		t.Skipf("⚠️ No hay cassette grabada en %s: grábala con make record-cassettes", path)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("❌ Error guardando la cassette %s: %v", nombre, err)
+		}
+		for _, unused := range recorder.Unused() {
+			logger.Printf("  📼 Interacción grabada no usada: %s", unused)
+		}
+	})
+	logger.Printf("📼 Cassette %s en modo %s (estricto: %v)", nombre, recorder.Mode, recorder.Strict)
+	return recorder.Client(30 * time.Second)
+}
+
+// vcrServer sirve una página HTML, un cuerpo binario y cuenta las peticiones recibidas
+func vcrServer(hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		switch r.URL.Path {
+		case "/binary":
+			w.Write([]byte{0xff, 0x00, 0xfe})
+		case "/missing":
+			http.NotFound(w, r)
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "sesion", Value: "secreto", Path: "/", Secure: true, HttpOnly: true})
+			w.Write([]byte("ok"))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(fmt.Sprintf(`<html><head><title>VCR %s</title></head><body><a href="/binary">bin</a></body></html>`, r.URL.Query().Get("v"))))
+		}
+	}))
+}
+
+func leer(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestVCRRecorder(t *testing.T) {
+	var hits int32
+	server := vcrServer(&hits)
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	// Grabación
+	recorder, err := vcr.New(cassette, vcr.ModeRecord)
+	require.NoError(t, err)
+	client := recorder.Client(5 * time.Second)
+	_, page := leer(t, client, server.URL+"/?v=1&t=100")
+	_, binary := leer(t, client, server.URL+"/binary")
+	status, _ := leer(t, client, server.URL+"/missing")
+	require.Equal(t, http.StatusNotFound, status)
+	require.NoError(t, recorder.Save())
+	require.EqualValues(t, 3, atomic.LoadInt32(&hits))
+	server.Close()
+
+	// Reproducción estricta, con el servidor ya apagado
+	recorder, err = vcr.New(cassette, vcr.ModeReplay)
+	require.NoError(t, err)
+	recorder.Strict = true
+	client = recorder.Client(5 * time.Second)
+
+	_, replayed := leer(t, client, server.URL+"/?t=100&v=1")
+	require.Equal(t, page, replayed, "❌ El orden de la query no debe afectar a la coincidencia")
+	_, replayed = leer(t, client, server.URL+"/binary")
+	require.Equal(t, binary, replayed, "❌ Los cuerpos binarios deben reproducirse intactos")
+	status, _ = leer(t, client, server.URL+"/missing")
+	require.Equal(t, http.StatusNotFound, status)
+	require.Empty(t, recorder.Unused())
+
+	// Una misma petición puede reproducirse varias veces
+	_, replayed = leer(t, client, server.URL+"/binary")
+	require.Equal(t, binary, replayed)
+
+	_, err = client.Get(server.URL + "/?v=1&t=200")
+	var unrecorded *vcr.UnrecordedError
+	require.True(t, errors.As(err, &unrecorded), "❌ En modo estricto una petición no grabada debe fallar: %v", err)
+
+	// Regla de coincidencia que ignora el parámetro de cache
+	recorder.Matcher = vcr.All(vcr.MatchMethod, vcr.IgnoreQuery("t"))
+	_, replayed = leer(t, client, server.URL+"/?v=1&t=200")
+	require.Equal(t, page, replayed)
+
+	// Los fetchers de las páginas usan el mismo transporte
+	home := pages.NewHomePage()
+	home.URL = server.URL + "/?v=1&t=100"
+	home.SetClient(client)
+	title, err := home.GetTitle()
+	require.NoError(t, err)
+	require.Equal(t, "VCR 1", title)
+
+	sandbox := pages.NewSandboxPage()
+	sandbox.URL = home.URL
+	sandbox.SetClient(client)
+	title, err = sandbox.GetSandboxTitle()
+	require.NoError(t, err)
+	require.Equal(t, "VCR 1", title)
+}
+
+func TestVCRRedaction(t *testing.T) {
+	var hits int32
+	server := vcrServer(&hits)
+	defer server.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := vcr.New(cassette, vcr.ModeRecord)
+	require.NoError(t, err)
+	req, err := http.NewRequest("GET", server.URL+"/login", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token-secreto")
+	req.Header.Set("Cookie", "previa=secreto")
+	resp, err := recorder.Client(5 * time.Second).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "secreto", resp.Cookies()[0].Value, "❌ La respuesta real no debe alterarse")
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secreto", "❌ La cassette no debe guardar credenciales ni cookies")
+
+	// Al reproducir se conservan el nombre y los atributos de la cookie para la auditoría
+	recorder, err = vcr.New(cassette, vcr.ModeReplay)
+	require.NoError(t, err)
+	recorder.Strict = true
+	resp, err = recorder.Client(5 * time.Second).Get(server.URL + "/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	cookies := resp.Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, "sesion", cookies[0].Name)
+	require.NotEqual(t, "secreto", cookies[0].Value)
+	require.True(t, cookies[0].Secure)
+	require.True(t, cookies[0].HttpOnly)
+}
+
+func TestVCRLiveMode(t *testing.T) {
+	var hits int32
+	server := vcrServer(&hits)
+	defer server.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	// En modo live las peticiones salen a la red y no se graba nada
+	recorder, err := vcr.New(cassette, vcr.ModeLive)
+	require.NoError(t, err)
+	client := recorder.Client(5 * time.Second)
+	leer(t, client, server.URL+"/?v=1")
+	leer(t, client, server.URL+"/?v=1")
+	require.NoError(t, recorder.Save())
+	require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+	require.Empty(t, recorder.Cassette().Interactions)
+	_, err = os.Stat(cassette)
+	require.True(t, errors.Is(err, os.ErrNotExist), "❌ El modo live no debe escribir la cassette")
+}
+
+func TestVCRModeFromEnv(t *testing.T) {
+	t.Setenv("VCR_MODE", "")
+	t.Setenv("VCR_STRICT", "")
+	mode, strict, err := vcr.ModeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, vcr.ModeLive, mode, "❌ Grabar o reproducir debe pedirse expresamente")
+	require.False(t, strict)
+
+	t.Setenv("VCR_MODE", "auto")
+	mode, _, err = vcr.ModeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, vcr.ModeAuto, mode)
+
+	t.Setenv("VCR_MODE", "replay")
+	t.Setenv("VCR_STRICT", "1")
+	mode, strict, err = vcr.ModeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, vcr.ModeReplay, mode)
+	require.True(t, strict)
+
+	t.Setenv("VCR_MODE", "rewind")
+	_, _, err = vcr.ModeFromEnv()
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+// tests/e2e/visual_test.go
+
+package e2e
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GoLang_FRT_E2E_Tests/pkg/visual"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func visualFixture(t *testing.T, changed ...visual.Region) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.RGBA{R: 240, G: 240, B: 240, A: 255})
+		}
+	}
+	for _, region := range changed {
+		for y := region.Y; y < region.Y+region.Height; y++ {
+			for x := region.X; x < region.X+region.Width; x++ {
+				img.Set(x, y, color.RGBA{B: 200, A: 255})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestVisualCompare(t *testing.T) {
+	dynamic := visual.Region{X: 5, Y: 5, Width: 10, Height: 4}
+
+	result, err := visual.CompareBytes(visualFixture(t), visualFixture(t, dynamic), visual.DefaultOptions())
+	require.NoError(t, err)
+	assert.False(t, result.Match)
+	assert.Equal(t, 40, result.DiffPixels)
+
+	opts := visual.DefaultOptions()
+	opts.Ignore = []visual.Region{dynamic}
+	result, err = visual.CompareBytes(visualFixture(t), visualFixture(t, dynamic), opts)
+	require.NoError(t, err)
+	assert.True(t, result.Match, "❌ Las zonas ignoradas no deben contar como diferencia")
+
+	opts = visual.DefaultOptions()
+	opts.MaxDiffPixels = 40
+	result, err = visual.CompareBytes(visualFixture(t), visualFixture(t, dynamic), opts)
+	require.NoError(t, err)
+	assert.True(t, result.Match, "❌ Debe tolerar hasta MaxDiffPixels")
+}
+
+func TestVisualStore(t *testing.T) {
+	baselines, reports := t.TempDir(), t.TempDir()
+	store := visual.NewStore(baselines, reports)
+	store.Update = false
+
+	// Sin referencia la prueba falla y la captura queda pendiente de aprobar
+	_, err := store.Assert("home", visualFixture(t), visual.DefaultOptions())
+	var missing *visual.MissingBaselineError
+	require.True(t, errors.As(err, &missing), "❌ Debe devolver MissingBaselineError: %v", err)
+	assert.FileExists(t, missing.Actual)
+	assert.NoFileExists(t, store.BaselinePath("home"))
+
+	// El modo actualización guarda la referencia
+	store.Update = true
+	result, err := store.Assert("home", visualFixture(t), visual.DefaultOptions())
+	require.NoError(t, err)
+	assert.True(t, result.Match)
+	assert.FileExists(t, store.BaselinePath("home"))
+	assert.NoFileExists(t, missing.Actual)
+	store.Update = false
+
+	// Una captura distinta genera la diferencia y el tríptico
+	_, err = store.Assert("home", visualFixture(t, visual.Region{X: 0, Y: 0, Width: 40, Height: 10}), visual.DefaultOptions())
+	var mismatch *visual.MismatchError
+	require.True(t, errors.As(err, &mismatch), "❌ Debe devolver MismatchError: %v", err)
+	assert.FileExists(t, filepath.Join(baselines, "home"+visual.ActualSuffix))
+	assert.FileExists(t, filepath.Join(reports, "home"+visual.DiffSuffix))
+	assert.FileExists(t, mismatch.Triptych)
+
+	triptych, err := os.ReadFile(mismatch.Triptych)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(triptych))
+	require.NoError(t, err)
+	assert.Equal(t, 40*3+20, img.Bounds().Dx())
+
+	// Volver a la captura original limpia los artefactos
+	_, err = store.Assert("home", visualFixture(t), visual.DefaultOptions())
+	require.NoError(t, err)
+	assert.NoFileExists(t, mismatch.Triptych)
+}